@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	agentskills "github.com/c8ab/agentskills-go"
+)
+
+func runFmt(e *env, args []string) int {
+	fs := newFlagSet(e, "fmt", "<dir...>")
+	recursive := recursiveFlag(fs)
+	check := fs.Bool("check", false, "list files that are not in canonical form instead of rewriting them")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return flagExitCode(err)
+	}
+	if len(args) == 0 {
+		e.errorf("fmt: no skill directories given")
+		fs.Usage()
+		return exitUsage
+	}
+
+	dirs, err := expandDirs(args, *recursive)
+	if err != nil {
+		e.errorf("fmt: %v", err)
+		return exitFailure
+	}
+
+	code := exitOK
+	for _, dir := range dirs {
+		file, changed, err := formatSkill(dir, !*check)
+		if err != nil {
+			e.errorf("fmt: %s", describeError(dir, err))
+			code = exitFailure
+			continue
+		}
+		if !changed {
+			continue
+		}
+		fmt.Fprintln(e.stdout, file)
+		if *check {
+			code = exitFailure
+		}
+	}
+	return code
+}
+
+// formatSkill formats the SKILL.md in dir and reports whether it was not in
+// canonical form. With write, the file is rewritten in place.
+func formatSkill(dir string, write bool) (file string, changed bool, err error) {
+	file, err = skillMDPath(dir)
+	if err != nil {
+		return "", false, err
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		return "", false, err
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", false, err
+	}
+	out, err := agentskills.Format(content)
+	if err != nil {
+		var pe *agentskills.ParseError
+		if errors.As(err, &pe) && pe.Path == "" {
+			pe.Path = file
+		}
+		return "", false, err
+	}
+	if bytes.Equal(out, content) {
+		return file, false, nil
+	}
+	if write {
+		if err := os.WriteFile(file, out, info.Mode().Perm()); err != nil {
+			return "", false, err
+		}
+	}
+	return file, true, nil
+}
+
+// skillMDPath returns the path of the SKILL.md (or skill.md) file in dir,
+// preferring SKILL.md as the library does.
+func skillMDPath(dir string) (string, error) {
+	for _, name := range []string{"SKILL.md", "skill.md"} {
+		p := filepath.Join(dir, name)
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p, nil
+		}
+	}
+	return "", agentskills.ErrSkillMDNotFound
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	agentskills "github.com/c8ab/agentskills-go"
+)
+
+// metadataFlag collects repeated key=value flags.
+type metadataFlag map[string]string
+
+func (m metadataFlag) String() string {
+	var pairs []string
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (m metadataFlag) Set(s string) error {
+	k, v, ok := strings.Cut(s, "=")
+	if !ok || k == "" {
+		return fmt.Errorf("must be key=value")
+	}
+	m[k] = v
+	return nil
+}
+
+func runInit(e *env, args []string) int {
+	fs := newFlagSet(e, "init", "<name>")
+	var opts agentskills.ScaffoldOptions
+	parent := fs.String("dir", ".", "parent `directory` of the new skill")
+	fs.StringVar(&opts.Description, "description", "", "skill `description`")
+	fs.StringVar(&opts.License, "license", "", "`license` field")
+	fs.StringVar(&opts.Compatibility, "compatibility", "", "`compatibility` field")
+	fs.StringVar(&opts.AllowedTools, "allowed-tools", "", "allowed-tools field, such as `Bash(git:*)`")
+	metadata := metadataFlag{}
+	fs.Var(metadata, "metadata", "metadata entry as `key=value` (repeatable)")
+	fs.BoolVar(&opts.Scripts, "scripts", false, "create a scripts/ directory")
+	fs.BoolVar(&opts.References, "references", false, "create a references/ directory")
+	fs.BoolVar(&opts.Assets, "assets", false, "create an assets/ directory")
+	template := fs.String("template", "", "template `directory` copied into the new skill")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return flagExitCode(err)
+	}
+	if len(args) != 1 {
+		e.errorf("init: expected exactly one skill name")
+		fs.Usage()
+		return exitUsage
+	}
+
+	if len(metadata) > 0 {
+		opts.Metadata = metadata
+	}
+	if *template != "" {
+		info, err := os.Stat(*template)
+		if err != nil || !info.IsDir() {
+			e.errorf("init: template %s is not a directory", *template)
+			return exitFailure
+		}
+		opts.Template = os.DirFS(*template)
+	}
+
+	dir, err := agentskills.Scaffold(*parent, args[0], opts)
+	if err != nil {
+		e.errorf("init: %v", err)
+		return exitFailure
+	}
+	fmt.Fprintf(e.stdout, "created %s\n", dir)
+	return exitOK
+}
@@ -0,0 +1,232 @@
+// Command skills-ref validates Agent Skills, prints their properties and
+// generates the agent prompt block for them.
+//
+// Usage:
+//
+//	skills-ref <command> [flags] <dir...>
+//
+// Commands:
+//
+//	validate         Check skill directories against the specification
+//	read-properties  Print the frontmatter properties of skills as JSON
+//	to-prompt        Print the skills for agent prompts: xml (or text), markdown, json or plain
+//	init             Create a new skill directory with a valid SKILL.md
+//	fmt              Rewrite SKILL.md files in canonical form
+//
+// Directory arguments may be glob patterns, which are expanded even when the
+// shell does not, and -r searches them recursively for skills.
+//
+// Exit codes: 0 on success, 1 when a skill is invalid or cannot be read,
+// and 2 on a usage error.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	agentskills "github.com/c8ab/agentskills-go"
+)
+
+// Exit codes.
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+// command is a skills-ref subcommand.
+type command struct {
+	name    string
+	summary string
+	run     func(env *env, args []string) int
+}
+
+// commands lists the subcommands in the order they are documented.
+var commands = []command{
+	{"validate", "Check skill directories against the specification", runValidate},
+	{"read-properties", "Print the frontmatter properties of skills as JSON", runReadProperties},
+	{"to-prompt", "Print the skills for agent prompts: xml (or text), markdown, json or plain", runToPrompt},
+	{"init", "Create a new skill directory with a valid SKILL.md", runInit},
+	{"fmt", "Rewrite SKILL.md files in canonical form", runFmt},
+}
+
+// env holds the output streams of a run.
+type env struct {
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// errorf prints a message prefixed with the program name to stderr.
+func (e *env) errorf(format string, args ...any) {
+	fmt.Fprintf(e.stderr, "skills-ref: "+format+"\n", args...)
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	e := &env{stdout: stdout, stderr: stderr}
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
+	}
+
+	name := args[0]
+	switch name {
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+		return exitOK
+	}
+	for _, c := range commands {
+		if c.name == name {
+			return c.run(e, args[1:])
+		}
+	}
+
+	e.errorf("unknown command %q", name)
+	usage(stderr)
+	return exitUsage
+}
+
+// usage prints the list of commands to w.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: skills-ref <command> [flags] <dir...>")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-16s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "skills-ref <command> -h" for the flags of a command.`)
+}
+
+// newFlagSet returns a flag set for the command name whose usage message
+// shows argsUsage after the flags.
+func newFlagSet(e *env, name, argsUsage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "Usage: skills-ref %s [flags] %s\n\nFlags:\n", name, argsUsage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args with fs, allowing flags after positional
+// arguments, and returns the positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string) (positional []string, err error) {
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		if args[0] == "--" {
+			return append(positional, args[1:]...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// flagExitCode returns the exit code for an error returned by parseFlags.
+// The flag package has already printed the message.
+func flagExitCode(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	return exitUsage
+}
+
+// outputFormat is the value of a --format flag.
+type outputFormat string
+
+func (f *outputFormat) String() string { return string(*f) }
+
+func (f *outputFormat) Set(s string) error {
+	switch s {
+	case "text", "json":
+		*f = outputFormat(s)
+		return nil
+	}
+	return fmt.Errorf("must be text or json")
+}
+
+// formatFlag registers --format on fs with the given default.
+func formatFlag(fs *flag.FlagSet, def outputFormat) *outputFormat {
+	f := def
+	fs.Var(&f, "format", "output `format`: text or json")
+	return &f
+}
+
+// recursiveFlag registers -r and --recursive on fs.
+func recursiveFlag(fs *flag.FlagSet) *bool {
+	r := fs.Bool("r", false, "search the arguments recursively for skill directories")
+	fs.BoolVar(r, "recursive", false, "same as -r")
+	return r
+}
+
+// isGlob reports whether arg is a glob pattern.
+func isGlob(arg string) bool {
+	return strings.ContainsAny(arg, "*?[")
+}
+
+// describeError formats an error about the skill in dir, adding dir unless
+// the error already names the file it concerns.
+func describeError(dir string, err error) string {
+	var pe *agentskills.ParseError
+	if errors.As(err, &pe) && pe.Path != "" {
+		return err.Error()
+	}
+	return dir + ": " + err.Error()
+}
+
+// expandDirs resolves the directory arguments: glob patterns are expanded,
+// and with recursive each directory is searched for skills with Discover.
+func expandDirs(args []string, recursive bool) ([]string, error) {
+	var dirs []string
+	for _, arg := range args {
+		matches := []string{arg}
+		if isGlob(arg) {
+			var err error
+			matches, err = filepath.Glob(arg)
+			if err != nil {
+				return nil, fmt.Errorf("bad pattern %q: %w", arg, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no matches for %q", arg)
+			}
+		}
+
+		if !recursive {
+			dirs = append(dirs, matches...)
+			continue
+		}
+		for _, root := range matches {
+			skills, err := agentskills.Discover(root, agentskills.DiscoverOptions{})
+			if err != nil {
+				return nil, err
+			}
+			if len(skills) == 0 {
+				return nil, fmt.Errorf("no skills found in %s", root)
+			}
+			for _, s := range skills {
+				if s.SkillMD == "" && s.Err != nil {
+					// A directory that could not be searched.
+					return nil, s.Err
+				}
+				dirs = append(dirs, s.Dir)
+			}
+		}
+	}
+	return dirs, nil
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// runCLI runs the command line args and returns the exit code and output.
+func runCLI(t *testing.T, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	code = run(args, &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+// writeSkill creates a valid skill named name in dir.
+func writeSkill(t *testing.T, dir, name string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: " + name + "\ndescription: Test skill " + name + " for the command line.\nlicense: MIT\n---\nBody\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	tests := []struct {
+		args []string
+		want int
+	}{
+		{nil, exitUsage},
+		{[]string{"bogus"}, exitUsage},
+		{[]string{"help"}, exitOK},
+		{[]string{"validate"}, exitUsage},
+		{[]string{"validate", "-h"}, exitOK},
+		{[]string{"validate", "--format", "xml", "x"}, exitUsage},
+		{[]string{"read-properties"}, exitUsage},
+		{[]string{"to-prompt", "--nope", "x"}, exitUsage},
+	}
+	for _, tt := range tests {
+		if code, _, _ := runCLI(t, tt.args...); code != tt.want {
+			t.Errorf("run(%q) = %d, want %d", tt.args, code, tt.want)
+		}
+	}
+}
+
+func TestValidate_ExitCodes(t *testing.T) {
+	code, stdout, _ := runCLI(t, "validate", "../../testdata/valid-all-fields")
+	if code != exitOK {
+		t.Errorf("valid skill: exit %d, output:\n%s", code, stdout)
+	}
+	if !strings.Contains(stdout, "valid-all-fields: valid") {
+		t.Errorf("expected valid line, got:\n%s", stdout)
+	}
+
+	code, stdout, _ = runCLI(t, "validate", "../../testdata/invalid-uppercase")
+	if code != exitFailure {
+		t.Errorf("invalid skill: exit %d, want %d", code, exitFailure)
+	}
+	if !strings.Contains(stdout, "SKILL.md:2:7: error AS002:") {
+		t.Errorf("expected positioned finding, got:\n%s", stdout)
+	}
+
+	code, _, _ = runCLI(t, "validate", "../../testdata/valid-skill", "--strict")
+	if code != exitFailure {
+		t.Errorf("strict with warnings: exit %d, want %d", code, exitFailure)
+	}
+}
+
+func TestValidate_JSON(t *testing.T) {
+	code, stdout, _ := runCLI(t, "validate", "--format", "json", "../../testdata/valid-all-fields", "../../testdata/missing-name")
+	if code != exitFailure {
+		t.Errorf("exit %d, want %d", code, exitFailure)
+	}
+
+	var reports []skillReport
+	if err := json.Unmarshal([]byte(stdout), &reports); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if len(reports) != 2 || !reports[0].Valid || reports[1].Valid {
+		t.Fatalf("unexpected reports: %+v", reports)
+	}
+	if f := reports[1].Findings[0]; f.Code != "AS007" || f.Rule != "name-missing" || f.Severity != "error" {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+}
+
+func TestValidate_GlobAndRecursive(t *testing.T) {
+	root := t.TempDir()
+	writeSkill(t, filepath.Join(root, "a", "skill-a"), "skill-a")
+	writeSkill(t, filepath.Join(root, "b", "nested", "skill-b"), "skill-b")
+
+	code, stdout, stderr := runCLI(t, "validate", "-r", root)
+	if code != exitOK {
+		t.Fatalf("exit %d: %s%s", code, stdout, stderr)
+	}
+	if strings.Count(stdout, ": valid") != 2 {
+		t.Errorf("expected two skills, got:\n%s", stdout)
+	}
+
+	code, stdout, _ = runCLI(t, "validate", filepath.Join(root, "a", "*"))
+	if code != exitOK || strings.Count(stdout, ": valid") != 1 {
+		t.Errorf("glob: exit %d, output:\n%s", code, stdout)
+	}
+
+	if code, _, stderr := runCLI(t, "validate", filepath.Join(root, "nope-*")); code != exitFailure || !strings.Contains(stderr, "no matches") {
+		t.Errorf("unmatched glob: exit %d, stderr %q", code, stderr)
+	}
+}
+
+func TestValidate_RecursiveUnreadable(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Getuid() == 0 {
+		t.Skip("directory permissions are not enforced")
+	}
+	root := t.TempDir()
+	writeSkill(t, filepath.Join(root, "skill-a"), "skill-a")
+	locked := filepath.Join(root, "locked")
+	if err := os.Mkdir(locked, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(locked, 0); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chmod(locked, 0o755) })
+
+	code, _, stderr := runCLI(t, "validate", "-r", root)
+	if code != exitFailure || !strings.Contains(stderr, "permission denied") || strings.Contains(stderr, "SKILL.md not found") {
+		t.Errorf("exit %d, stderr %q", code, stderr)
+	}
+}
+
+func TestReadProperties(t *testing.T) {
+	code, stdout, _ := runCLI(t, "read-properties", "../../testdata/valid-all-fields")
+	if code != exitOK {
+		t.Fatalf("exit %d", code)
+	}
+	var props map[string]any
+	if err := json.Unmarshal([]byte(stdout), &props); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if props["name"] != "valid-all-fields" || props["allowed-tools"] != "Bash(git:*) Bash(jq:*)" {
+		t.Errorf("unexpected properties: %v", props)
+	}
+
+	code, stdout, _ = runCLI(t, "read-properties", "--format", "text", "../../testdata/valid-all-fields")
+	if code != exitOK || !strings.Contains(stdout, "metadata.author: Test Author\n") {
+		t.Errorf("text: exit %d, output:\n%s", code, stdout)
+	}
+
+	code, stdout, _ = runCLI(t, "read-properties", "../../testdata/valid-*")
+	var list []map[string]any
+	if err := json.Unmarshal([]byte(stdout), &list); err != nil || len(list) != 2 {
+		t.Errorf("glob: expected array of 2, got %v (exit %d)\n%s", err, code, stdout)
+	}
+
+	code, _, stderr := runCLI(t, "read-properties", "../../testdata/missing-name")
+	if code != exitFailure || !strings.Contains(stderr, "name") {
+		t.Errorf("missing name: exit %d, stderr %q", code, stderr)
+	}
+}
+
+func TestToPrompt(t *testing.T) {
+	code, stdout, _ := runCLI(t, "to-prompt", "../../testdata/valid-skill")
+	if code != exitOK {
+		t.Fatalf("exit %d", code)
+	}
+	if !strings.HasPrefix(stdout, "<available_skills>") || !strings.Contains(stdout, "<name>\nvalid-skill\n</name>") {
+		t.Errorf("unexpected prompt:\n%s", stdout)
+	}
+
+	code, stdout, _ = runCLI(t, "to-prompt", "--format", "json", "../../testdata/valid-skill")
+	var entries []map[string]string
+	if err := json.Unmarshal([]byte(stdout), &entries); err != nil || code != exitOK {
+		t.Fatalf("invalid JSON (exit %d): %v\n%s", code, err, stdout)
+	}
+	if len(entries) != 1 || entries[0]["name"] != "valid-skill" || !filepath.IsAbs(entries[0]["location"]) {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+
+	code, stdout, _ = runCLI(t, "to-prompt", "--format", "markdown", "../../testdata/valid-skill")
+	if code != exitOK || !strings.HasPrefix(stdout, "## Available skills\n\n- **valid-skill**: A valid test skill\n") {
+		t.Errorf("markdown: exit %d, output:\n%s", code, stdout)
+	}
+	_, xml, _ := runCLI(t, "to-prompt", "../../testdata/valid-skill")
+	code, stdout, _ = runCLI(t, "to-prompt", "--format", "text", "../../testdata/valid-skill")
+	if code != exitOK || stdout != xml || !strings.HasPrefix(stdout, "<available_skills>") {
+		t.Errorf("text: exit %d, output:\n%s", code, stdout)
+	}
+	if code, _, _ := runCLI(t, "to-prompt", "--format", "yaml", "../../testdata/valid-skill"); code != exitUsage {
+		t.Errorf("unknown format: exit %d, want %d", code, exitUsage)
+	}
+
+	tmpl := filepath.Join(t.TempDir(), "prompt.tmpl")
+	if err := os.WriteFile(tmpl, []byte("{{range .Skills}}{{.Name}} ({{.License}}){{end}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	code, stdout, _ = runCLI(t, "to-prompt", "--template", tmpl, "../../testdata/valid-all-fields")
+	if code != exitOK || stdout != "valid-all-fields (MIT)\n" {
+		t.Errorf("template: exit %d, output %q", code, stdout)
+	}
+
+	code, stdout, _ = runCLI(t, "to-prompt", "--format", "plain", "--query", "optional fields", "../../testdata/valid-skill", "../../testdata/valid-all-fields")
+	if code != exitOK || !strings.HasPrefix(stdout, "valid-all-fields: ") || strings.Count(stdout, "\n") != 1 {
+		t.Errorf("query: exit %d, stdout %q", code, stdout)
+	}
+
+	code, stdout, stderr := runCLI(t, "to-prompt", "--format", "plain", "--max-tokens", "20", "../../testdata/valid-skill", "../../testdata/valid-all-fields")
+	if code != exitOK || strings.Count(stdout, "\n") != 1 || !strings.Contains(stderr, "dropped valid-all-fields") {
+		t.Errorf("max-tokens: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+
+	code, stdout, _ = runCLI(t, "to-prompt", "--format", "plain", "--include", "compatibility,metadata:version", "--include", "allowed-tools", "--location", "none", "../../testdata/valid-all-fields")
+	if code != exitOK || stdout != "valid-all-fields: A skill with all optional fields (compatibility: Requires Python 3.11+; allowed-tools: Bash(git:*) Bash(jq:*); version: 1.0)\n" {
+		t.Errorf("include: exit %d, stdout %q", code, stdout)
+	}
+	if code, _, _ := runCLI(t, "to-prompt", "--include", "owner", "../../testdata/valid-skill"); code != exitUsage {
+		t.Errorf("unknown field: exit %d, want %d", code, exitUsage)
+	}
+
+	other := filepath.Join(t.TempDir(), "other-os")
+	if err := os.MkdirAll(other, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(other, "SKILL.md"), []byte("---\nname: other-os\ndescription: x\ncompatibility: \"os: plan9x\"\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	code, stdout, stderr = runCLI(t, "to-prompt", "--format", "plain", "--compatible", other, "../../testdata/valid-skill")
+	if code != exitOK || !strings.HasPrefix(stdout, "valid-skill: ") || strings.Count(stdout, "\n") != 1 || !strings.Contains(stderr, "left out other-os: requires os plan9x") {
+		t.Errorf("compatible: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+
+	code, stdout, _ = runCLI(t, "to-prompt", "--layout", "compact", "--location", "none", "../../testdata/valid-skill")
+	if code != exitOK || stdout != "<available_skills><skill><name>valid-skill</name><description>A valid test skill</description></skill></available_skills>\n" {
+		t.Errorf("compact layout: exit %d, stdout %q", code, stdout)
+	}
+
+	code, stdout, _ = runCLI(t, "to-prompt", "--format", "plain", "--location", "relative", "../../testdata/valid-skill")
+	if code != exitOK || stdout != "valid-skill: A valid test skill [../../testdata/valid-skill/SKILL.md]\n" {
+		t.Errorf("relative location: exit %d, stdout %q", code, stdout)
+	}
+	abs, err := filepath.Abs("../../testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, stdout, _ = runCLI(t, "to-prompt", "--format", "plain", "--map", abs+"=/mnt/skills", "../../testdata/valid-skill")
+	if code != exitOK || stdout != "valid-skill: A valid test skill [/mnt/skills/valid-skill/SKILL.md]\n" {
+		t.Errorf("mapped location: exit %d, stdout %q", code, stdout)
+	}
+	if code, _, _ := runCLI(t, "to-prompt", "--map", abs+"=/mnt", "--location", "none", "../../testdata/valid-skill"); code != exitUsage {
+		t.Errorf("--map with --location: exit %d, want %d", code, exitUsage)
+	}
+
+	if code, _, _ := runCLI(t, "to-prompt", "../../testdata/missing-name"); code != exitFailure {
+		t.Errorf("invalid skill: exit %d, want %d", code, exitFailure)
+	}
+}
+
+func TestInit(t *testing.T) {
+	parent := t.TempDir()
+	code, stdout, stderr := runCLI(t, "init", "--dir", parent, "--license", "MIT",
+		"--metadata", "owner=docs", "--references", "new-skill")
+	if code != exitOK {
+		t.Fatalf("exit %d: %s", code, stderr)
+	}
+	dir := filepath.Join(parent, "new-skill")
+	if !strings.Contains(stdout, dir) {
+		t.Errorf("expected created path in output, got %q", stdout)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "references", "README.md")); err != nil {
+		t.Error(err)
+	}
+	if code, stdout, _ := runCLI(t, "validate", "--strict", dir); code != exitOK {
+		t.Errorf("scaffolded skill does not validate:\n%s", stdout)
+	}
+
+	if code, _, _ := runCLI(t, "init", "--dir", parent, "new-skill"); code != exitFailure {
+		t.Errorf("existing skill: exit %d, want %d", code, exitFailure)
+	}
+	if code, _, _ := runCLI(t, "init", "--dir", parent, "Bad"); code != exitFailure {
+		t.Errorf("invalid name: exit %d, want %d", code, exitFailure)
+	}
+	if code, _, _ := runCLI(t, "init", "--metadata", "novalue", "x"); code != exitUsage {
+		t.Errorf("bad metadata flag: exit %d, want %d", code, exitUsage)
+	}
+}
+
+func TestFmt(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "messy-skill")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "SKILL.md")
+	messy := "---\r\nlicense: 'MIT'\r\ndescription: Formats things.\r\nname: messy-skill\r\n---\r\n# Body\r\n\r\n"
+	if err := os.WriteFile(file, []byte(messy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, _ := runCLI(t, "fmt", "--check", dir)
+	if code != exitFailure || strings.TrimSpace(stdout) != file {
+		t.Errorf("check: exit %d, output %q", code, stdout)
+	}
+	if content, _ := os.ReadFile(file); string(content) != messy {
+		t.Error("--check rewrote the file")
+	}
+
+	if code, stdout, _ := runCLI(t, "fmt", dir); code != exitOK || strings.TrimSpace(stdout) != file {
+		t.Errorf("fmt: exit %d, output %q", code, stdout)
+	}
+	want := "---\nname: messy-skill\ndescription: Formats things.\nlicense: MIT\n---\n\n# Body\n"
+	if content, _ := os.ReadFile(file); string(content) != want {
+		t.Errorf("formatted file = %q, want %q", content, want)
+	}
+
+	if code, stdout, _ := runCLI(t, "fmt", "--check", dir); code != exitOK || stdout != "" {
+		t.Errorf("check after fmt: exit %d, output %q", code, stdout)
+	}
+	if code, _, _ := runCLI(t, "fmt", t.TempDir()); code != exitFailure {
+		t.Errorf("missing SKILL.md: exit %d, want %d", code, exitFailure)
+	}
+}
+
+func TestValidate_Fix(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my-skill")
+	writeSkill(t, dir, "My--Skill")
+
+	code, stdout, _ := runCLI(t, "validate", dir)
+	if code != exitFailure || !strings.Contains(stdout, "(fixable: change name to 'my-skill')") {
+		t.Errorf("expected fixable findings, exit %d:\n%s", code, stdout)
+	}
+
+	code, stdout, stderr := runCLI(t, "validate", "--fix", dir)
+	if code != exitOK {
+		t.Fatalf("exit %d: %s%s", code, stdout, stderr)
+	}
+	if !strings.Contains(stdout, "fixed AS002: change name to 'my-skill'") || !strings.Contains(stdout, "my-skill: valid") {
+		t.Errorf("unexpected output:\n%s", stdout)
+	}
+}
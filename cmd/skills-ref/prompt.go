@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	agentskills "github.com/c8ab/agentskills-go"
+)
+
+// promptRenderers maps the values of to-prompt --format to renderers
+// showing the given optional fields. "text" is the XML block, as it was
+// before the other formats existed; "plain" is one line per skill.
+var promptRenderers = map[string]func(layout agentskills.XMLLayout, fields agentskills.PromptFields) agentskills.PromptRenderer{
+	"xml":  xmlPromptRenderer,
+	"text": xmlPromptRenderer,
+	"markdown": func(_ agentskills.XMLLayout, fields agentskills.PromptFields) agentskills.PromptRenderer {
+		return agentskills.MarkdownRenderer{Fields: fields}
+	},
+	"json": func(_ agentskills.XMLLayout, fields agentskills.PromptFields) agentskills.PromptRenderer {
+		return agentskills.JSONRenderer{Fields: fields}
+	},
+	"plain": func(_ agentskills.XMLLayout, fields agentskills.PromptFields) agentskills.PromptRenderer {
+		return agentskills.TextRenderer{Fields: fields}
+	},
+}
+
+func xmlPromptRenderer(layout agentskills.XMLLayout, fields agentskills.PromptFields) agentskills.PromptRenderer {
+	return agentskills.XMLRenderer{Layout: layout, Fields: fields}
+}
+
+// promptFormat is the value of the to-prompt --format flag.
+type promptFormat string
+
+func (f *promptFormat) String() string { return string(*f) }
+
+func (f *promptFormat) Set(s string) error {
+	if _, ok := promptRenderers[s]; !ok {
+		return fmt.Errorf("must be xml, markdown, json or plain")
+	}
+	*f = promptFormat(s)
+	return nil
+}
+
+// xmlLayouts maps the values of to-prompt --layout to XML layouts.
+var xmlLayouts = map[string]agentskills.XMLLayout{
+	"legacy":  agentskills.XMLLegacy,
+	"pretty":  agentskills.XMLPretty,
+	"compact": agentskills.XMLCompact,
+}
+
+// xmlLayout is the value of the to-prompt --layout flag.
+type xmlLayout string
+
+func (l *xmlLayout) String() string { return string(*l) }
+
+func (l *xmlLayout) Set(s string) error {
+	if _, ok := xmlLayouts[s]; !ok {
+		return fmt.Errorf("must be legacy, pretty or compact")
+	}
+	*l = xmlLayout(s)
+	return nil
+}
+
+// fieldsFlag collects the optional fields of repeated or comma-separated
+// to-prompt --include flags.
+type fieldsFlag agentskills.PromptFields
+
+func (f *fieldsFlag) String() string {
+	var names []string
+	if f.License {
+		names = append(names, "license")
+	}
+	if f.Compatibility {
+		names = append(names, "compatibility")
+	}
+	if f.AllowedTools {
+		names = append(names, "allowed-tools")
+	}
+	for _, key := range f.Metadata {
+		names = append(names, "metadata:"+key)
+	}
+	return strings.Join(names, ",")
+}
+
+func (f *fieldsFlag) Set(s string) error {
+	for _, name := range strings.Split(s, ",") {
+		switch key, isMetadata := strings.CutPrefix(name, "metadata:"); {
+		case name == "license":
+			f.License = true
+		case name == "compatibility":
+			f.Compatibility = true
+		case name == "allowed-tools":
+			f.AllowedTools = true
+		case isMetadata && key != "":
+			f.Metadata = append(f.Metadata, key)
+		default:
+			return fmt.Errorf("unknown field %q: must be license, compatibility, allowed-tools or metadata:key", name)
+		}
+	}
+	return nil
+}
+
+// promptLocations maps the values of to-prompt --location to location
+// functions; nil keeps absolute paths.
+var promptLocations = map[string]agentskills.LocationFunc{
+	"absolute": nil,
+	"relative": agentskills.RelativeLocation("."),
+	"uri":      agentskills.FileURILocation,
+	"none":     agentskills.OmitLocation,
+}
+
+// locationMode is the value of the to-prompt --location flag.
+type locationMode string
+
+func (m *locationMode) String() string { return string(*m) }
+
+func (m *locationMode) Set(s string) error {
+	if _, ok := promptLocations[s]; !ok {
+		return fmt.Errorf("must be absolute, relative, uri or none")
+	}
+	*m = locationMode(s)
+	return nil
+}
+
+// locationMap is the value of the to-prompt --map flag: a host directory
+// and the path the agent sees it at.
+type locationMap struct {
+	host, agent string
+}
+
+func (m *locationMap) String() string {
+	if m.host == "" {
+		return ""
+	}
+	return m.host + "=" + m.agent
+}
+
+func (m *locationMap) Set(s string) error {
+	host, agent, ok := strings.Cut(s, "=")
+	if !ok || host == "" || agent == "" {
+		return fmt.Errorf("must be host-dir=agent-dir")
+	}
+	m.host, m.agent = host, agent
+	return nil
+}
+
+func runToPrompt(e *env, args []string) int {
+	fs := newFlagSet(e, "to-prompt", "<dir...>")
+	format := promptFormat("xml")
+	fs.Var(&format, "format", "output `format`: xml (or its older name text), markdown, json or plain")
+	layout := xmlLayout("legacy")
+	fs.Var(&layout, "layout", "XML `layout`: legacy, pretty or compact")
+	var fields fieldsFlag
+	fs.Var(&fields, "include", "also show the optional `fields`: license, compatibility, allowed-tools or metadata:key (repeatable)")
+	recursive := recursiveFlag(fs)
+	templateFile := fs.String("template", "", "render with the text/template in `file` instead of --format")
+	query := fs.String("query", "", "list only the skills relevant to `text`, most relevant first")
+	top := fs.Int("top", 0, "with --query, list at most `k` skills (0: all that match)")
+	location := locationMode("absolute")
+	fs.Var(&location, "location", "show locations as `mode`: absolute, relative (to the working directory), uri or none")
+	var mapping locationMap
+	fs.Var(&mapping, "map", "show locations under host-dir as under agent-dir, given as `host-dir=agent-dir`")
+	compatible := fs.Bool("compatible", false, "leave out skills whose structured compatibility rules out this machine")
+	maxTokens := fs.Int("max-tokens", 0, "shorten descriptions and drop skills to fit `n` tokens (0: no limit)")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return flagExitCode(err)
+	}
+	if len(args) == 0 {
+		e.errorf("to-prompt: no skill directories given")
+		fs.Usage()
+		return exitUsage
+	}
+
+	if mapping.host != "" && location != "absolute" {
+		e.errorf("to-prompt: --map cannot be combined with --location %s", location)
+		return exitUsage
+	}
+
+	dirs, err := expandDirs(args, *recursive)
+	if err != nil {
+		e.errorf("to-prompt: %v", err)
+		return exitFailure
+	}
+
+	renderer := promptRenderers[string(format)](xmlLayouts[string(layout)], agentskills.PromptFields(fields))
+	if *templateFile != "" {
+		text, err := os.ReadFile(*templateFile)
+		if err != nil {
+			e.errorf("to-prompt: %v", err)
+			return exitFailure
+		}
+		if renderer, err = agentskills.NewTemplateRenderer(string(text)); err != nil {
+			e.errorf("to-prompt: %v", err)
+			return exitFailure
+		}
+	}
+
+	skills, err := agentskills.PromptSkills(dirs)
+	if err != nil {
+		e.errorf("to-prompt: %v", err)
+		return exitFailure
+	}
+	if *query != "" {
+		skills = agentskills.NewSelector(skills).Select(*query, *top)
+	}
+	opts := agentskills.PromptOptions{Renderer: renderer, MaxTokens: *maxTokens, Location: promptLocations[string(location)]}
+	if *compatible {
+		opts.Environment = agentskills.ProbeEnvironment(context.Background())
+	}
+	if mapping.host != "" {
+		opts.Location = agentskills.MapLocation(mapping.host, mapping.agent)
+	}
+	result, err := agentskills.RenderPrompt(skills, opts)
+	if err != nil {
+		e.errorf("to-prompt: %v", err)
+		return exitFailure
+	}
+	for _, x := range result.Exclusions {
+		e.errorf("to-prompt: left out %s: %s", x.Name, strings.Join(x.Reasons, "; "))
+	}
+	for _, o := range result.Omissions {
+		if o.Dropped {
+			e.errorf("to-prompt: dropped %s to fit %d tokens", o.Name, *maxTokens)
+		} else {
+			e.errorf("to-prompt: shortened the description of %s to fit %d tokens", o.Name, *maxTokens)
+		}
+	}
+	fmt.Fprintln(e.stdout, result.Prompt)
+	return exitOK
+}
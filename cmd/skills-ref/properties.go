@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	agentskills "github.com/c8ab/agentskills-go"
+)
+
+func runReadProperties(e *env, args []string) int {
+	fs := newFlagSet(e, "read-properties", "<dir...>")
+	format := formatFlag(fs, "json")
+	recursive := recursiveFlag(fs)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return flagExitCode(err)
+	}
+	if len(args) == 0 {
+		e.errorf("read-properties: no skill directory given")
+		fs.Usage()
+		return exitUsage
+	}
+
+	dirs, err := expandDirs(args, *recursive)
+	if err != nil {
+		e.errorf("read-properties: %v", err)
+		return exitFailure
+	}
+
+	code := exitOK
+	props := make([]*agentskills.SkillProperties, 0, len(dirs))
+	for _, dir := range dirs {
+		p, err := agentskills.ReadProperties(dir)
+		if err != nil {
+			e.errorf("read-properties: %s", describeError(dir, err))
+			code = exitFailure
+			continue
+		}
+		props = append(props, p)
+	}
+
+	// A single directory prints an object; globs, -r and several
+	// directories print an array.
+	single := len(args) == 1 && !*recursive && !isGlob(args[0])
+
+	if *format == "json" {
+		var out any
+		if single {
+			if len(props) == 0 {
+				return code
+			}
+			out = props[0].ToMap()
+		} else {
+			maps := make([]map[string]any, 0, len(props))
+			for _, p := range props {
+				maps = append(maps, p.ToMap())
+			}
+			out = maps
+		}
+		if err := writeJSON(e.stdout, out); err != nil {
+			e.errorf("read-properties: %v", err)
+			return exitFailure
+		}
+		return code
+	}
+
+	for i, p := range props {
+		if i > 0 {
+			fmt.Fprintln(e.stdout)
+		}
+		writeProperties(e.stdout, p)
+	}
+	return code
+}
+
+// writeProperties prints the non-empty properties as "key: value" lines.
+func writeProperties(w io.Writer, p *agentskills.SkillProperties) {
+	fields := []struct{ key, value string }{
+		{"name", p.Name},
+		{"description", p.Description},
+		{"license", p.License},
+		{"compatibility", p.Compatibility},
+		{"allowed-tools", p.AllowedTools},
+	}
+	for _, f := range fields {
+		if f.value != "" {
+			fmt.Fprintf(w, "%s: %s\n", f.key, f.value)
+		}
+	}
+
+	keys := make([]string, 0, len(p.Metadata))
+	for k := range p.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, "metadata.%s: %s\n", k, p.Metadata[k])
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	agentskills "github.com/c8ab/agentskills-go"
+)
+
+// skillReport is the JSON output of validate for one skill directory.
+type skillReport struct {
+	Dir      string    `json:"dir"`
+	Valid    bool      `json:"valid"`
+	Findings []finding `json:"findings"`
+	Fixed    []finding `json:"fixed,omitempty"`
+}
+
+// finding is the JSON form of an agentskills.ValidationError.
+type finding struct {
+	Code     string `json:"code,omitempty"`
+	Rule     string `json:"rule,omitempty"`
+	Severity string `json:"severity"`
+	Field    string `json:"field,omitempty"`
+	Message  string `json:"message"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Fix      string `json:"fix,omitempty"`
+}
+
+// newFinding converts a validation finding for JSON output.
+func newFinding(err error) finding {
+	var ve *agentskills.ValidationError
+	if !errors.As(err, &ve) {
+		return finding{Severity: agentskills.SeverityError.String(), Message: err.Error()}
+	}
+	f := finding{
+		Code:     string(ve.Code),
+		Rule:     ve.Code.Name(),
+		Severity: ve.Severity.String(),
+		Field:    ve.Field,
+		Message:  ve.Error(),
+		File:     ve.Pos.File,
+		Line:     ve.Pos.Line,
+		Column:   ve.Pos.Column,
+	}
+	if ve.Fix != nil {
+		f.Fix = ve.Fix.Description
+	}
+	return f
+}
+
+func runValidate(e *env, args []string) int {
+	fs := newFlagSet(e, "validate", "<dir...>")
+	format := formatFlag(fs, "text")
+	recursive := recursiveFlag(fs)
+	strict := fs.Bool("strict", false, "treat warnings as errors")
+	fix := fs.Bool("fix", false, "apply suggested fixes, then validate again")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return flagExitCode(err)
+	}
+	if len(args) == 0 {
+		e.errorf("validate: no skill directories given")
+		fs.Usage()
+		return exitUsage
+	}
+
+	dirs, err := expandDirs(args, *recursive)
+	if err != nil {
+		e.errorf("validate: %v", err)
+		return exitFailure
+	}
+
+	reports := make([]skillReport, 0, len(dirs))
+	code := exitOK
+	for _, dir := range dirs {
+		opts := agentskills.ValidateOptions{Strict: *strict}
+		result := agentskills.Check(dir, opts)
+		var fixed []finding
+		if *fix {
+			fr, err := agentskills.ApplyFixes(dir, result.Errors)
+			for _, ve := range fr.Applied {
+				fixed = append(fixed, newFinding(ve))
+			}
+			if err != nil {
+				e.errorf("validate: %s", describeError(dir, err))
+				code = exitFailure
+			}
+			if len(fr.Applied) > 0 {
+				dir = fr.Dir
+				result = agentskills.Check(dir, opts)
+			}
+		}
+
+		report := skillReport{Dir: dir, Valid: !result.HasErrors(), Findings: []finding{}, Fixed: fixed}
+		for _, err := range result.Errors {
+			report.Findings = append(report.Findings, newFinding(err))
+		}
+		if !report.Valid {
+			code = exitFailure
+		}
+		reports = append(reports, report)
+	}
+
+	if *format == "json" {
+		if err := writeJSON(e.stdout, reports); err != nil {
+			e.errorf("validate: %v", err)
+			return exitFailure
+		}
+		return code
+	}
+
+	for _, r := range reports {
+		for _, f := range r.Fixed {
+			fmt.Fprintf(e.stdout, "%s: fixed %s: %s\n", r.Dir, f.Code, f.Fix)
+		}
+		for _, f := range r.Findings {
+			loc := r.Dir
+			if f.Line > 0 {
+				loc = fmt.Sprintf("%s:%d:%d", f.File, f.Line, f.Column)
+			} else if f.File != "" {
+				loc = f.File
+			}
+			fixHint := ""
+			if f.Fix != "" {
+				fixHint = " (fixable: " + f.Fix + ")"
+			}
+			if f.Code != "" {
+				fmt.Fprintf(e.stdout, "%s: %s %s: %s%s\n", loc, f.Severity, f.Code, f.Message, fixHint)
+			} else {
+				fmt.Fprintf(e.stdout, "%s: %s: %s%s\n", loc, f.Severity, f.Message, fixHint)
+			}
+		}
+		if r.Valid {
+			fmt.Fprintf(e.stdout, "%s: valid\n", r.Dir)
+		}
+	}
+	return code
+}
+
+// writeJSON writes v to w as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
@@ -0,0 +1,180 @@
+package agentskills
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Code is the stable identifier of a validation check, such as "AS001".
+// Codes never change meaning between releases, so they can be used to
+// suppress, count and document findings without matching message text.
+type Code string
+
+// Validation rule codes.
+const (
+	CodeNameTooLong            Code = "AS001"
+	CodeNameNotLowercase       Code = "AS002"
+	CodeNameHyphenBoundary     Code = "AS003"
+	CodeNameConsecutiveHyphens Code = "AS004"
+	CodeNameInvalidCharacters  Code = "AS005"
+	CodeNameDirectoryMismatch  Code = "AS006"
+	CodeNameMissing            Code = "AS007"
+	CodeNameEmpty              Code = "AS008"
+
+	CodeDescriptionMissing  Code = "AS101"
+	CodeDescriptionEmpty    Code = "AS102"
+	CodeDescriptionTooLong  Code = "AS103"
+	CodeDescriptionTooShort Code = "AS104"
+
+	CodeCompatibilityTooLong Code = "AS201"
+	CodeCompatibilityInvalid Code = "AS202"
+
+	CodeUnexpectedField Code = "AS301"
+
+	CodePathNotFound      Code = "AS401"
+	CodePathNotDirectory  Code = "AS402"
+	CodeSkillMDMissing    Code = "AS403"
+	CodeSkillMDUnreadable Code = "AS404"
+	CodeSkillMDLowercase  Code = "AS405"
+
+	CodeFrontmatterMissing    Code = "AS501"
+	CodeFrontmatterUnclosed   Code = "AS502"
+	CodeFrontmatterInvalid    Code = "AS503"
+	CodeFrontmatterNotMapping Code = "AS504"
+
+	CodeLicenseMissing Code = "AS601"
+	CodeBodyEmpty      Code = "AS602"
+
+	CodeConfigInvalid Code = "AS701"
+
+	CodeAllowedToolsInvalid Code = "AS801"
+)
+
+// Severity classifies how serious a validation finding is.
+type Severity int
+
+// Severity levels, from most to least serious. The zero value is
+// SeverityError. Only errors make Validate fail; warnings and infos are
+// reported by Check.
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+// String returns the lowercase name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseSeverity returns the severity named s: "error", "warning" or "info".
+func ParseSeverity(s string) (Severity, error) {
+	switch s {
+	case "error":
+		return SeverityError, nil
+	case "warning":
+		return SeverityWarning, nil
+	case "info":
+		return SeverityInfo, nil
+	default:
+		return 0, fmt.Errorf("unknown severity %q", s)
+	}
+}
+
+// RuleInfo documents a validation check.
+type RuleInfo struct {
+	// Code is the stable identifier, such as "AS001".
+	Code Code
+
+	// Name is a short kebab-case name, such as "name-too-long".
+	Name string
+
+	// Description explains what the check reports.
+	Description string
+
+	// Severity is the default severity of the findings.
+	Severity Severity
+
+	// err is the sentinel error reported by the check.
+	err error
+}
+
+// builtinRules lists every check performed by Validate, in code order.
+var builtinRules = []RuleInfo{
+	{CodeNameTooLong, "name-too-long", "Skill name exceeds the maximum length", SeverityError, ErrNameTooLong},
+	{CodeNameNotLowercase, "name-not-lowercase", "Skill name contains uppercase letters", SeverityError, ErrNameNotLowercase},
+	{CodeNameHyphenBoundary, "name-hyphen-boundary", "Skill name starts or ends with a hyphen", SeverityError, ErrNameLeadingHyphen},
+	{CodeNameConsecutiveHyphens, "name-consecutive-hyphens", "Skill name contains consecutive hyphens", SeverityError, ErrNameConsecutiveHyphen},
+	{CodeNameInvalidCharacters, "name-invalid-characters", "Skill name contains characters other than letters, digits and hyphens", SeverityError, ErrNameInvalidChars},
+	{CodeNameDirectoryMismatch, "name-directory-mismatch", "Skill name does not match the directory name", SeverityError, ErrNameDirectoryMismatch},
+	{CodeNameMissing, "name-missing", "Frontmatter has no name field", SeverityError, ErrMissingName},
+	{CodeNameEmpty, "name-empty", "Name field is empty or not a string", SeverityError, ErrNameEmpty},
+	{CodeDescriptionMissing, "description-missing", "Frontmatter has no description field", SeverityError, ErrMissingDescription},
+	{CodeDescriptionEmpty, "description-empty", "Description field is empty or not a string", SeverityError, ErrDescriptionEmpty},
+	{CodeDescriptionTooLong, "description-too-long", "Description exceeds the maximum length", SeverityError, ErrDescriptionTooLong},
+	{CodeDescriptionTooShort, "description-too-short", "Description is too short to tell agents when to use the skill", SeverityWarning, ErrDescriptionTooShort},
+	{CodeCompatibilityTooLong, "compatibility-too-long", "Compatibility exceeds the maximum length", SeverityError, ErrCompatibilityTooLong},
+	{CodeCompatibilityInvalid, "compatibility-invalid", "Structured compatibility (starting with a lower-case os:, arch:, bin: or runtime: key) cannot be parsed", SeverityWarning, ErrCompatibilityInvalid},
+	{CodeUnexpectedField, "unexpected-field", "Frontmatter contains a field not defined by the specification", SeverityError, ErrUnexpectedField},
+	{CodePathNotFound, "path-not-found", "Skill directory does not exist or cannot be accessed", SeverityError, ErrPathNotExist},
+	{CodePathNotDirectory, "path-not-directory", "Skill path is not a directory", SeverityError, ErrPathNotDirectory},
+	{CodeSkillMDMissing, "skill-md-missing", "Skill directory has no SKILL.md file", SeverityError, ErrSkillMDNotFound},
+	{CodeSkillMDUnreadable, "skill-md-unreadable", "SKILL.md cannot be read", SeverityError, ErrSkillMDUnreadable},
+	{CodeSkillMDLowercase, "skill-md-lowercase", "Skill file is named skill.md instead of SKILL.md", SeverityWarning, ErrSkillMDLowercase},
+	{CodeFrontmatterMissing, "frontmatter-missing", "SKILL.md does not start with a --- line", SeverityError, ErrMissingFrontmatter},
+	{CodeFrontmatterUnclosed, "frontmatter-unclosed", "Frontmatter has no closing --- line", SeverityError, ErrUnclosedFrontmatter},
+	{CodeFrontmatterInvalid, "frontmatter-invalid-yaml", "Frontmatter is not valid YAML", SeverityError, ErrInvalidYAML},
+	{CodeFrontmatterNotMapping, "frontmatter-not-mapping", "Frontmatter is not a YAML mapping", SeverityError, ErrFrontmatterNotMapping},
+	{CodeLicenseMissing, "license-missing", "Frontmatter has no license field", SeverityWarning, ErrLicenseMissing},
+	{CodeBodyEmpty, "body-empty", "SKILL.md has no instructions after the frontmatter", SeverityInfo, ErrBodyEmpty},
+	{CodeConfigInvalid, "config-invalid", "The .agentskills.yaml config file cannot be read or applied", SeverityError, ErrInvalidConfig},
+	{CodeAllowedToolsInvalid, "allowed-tools-invalid", "Allowed-tools is not a space-separated list of tools and patterns", SeverityError, ErrAllowedToolsInvalid},
+}
+
+// BuiltinRules returns documentation for every check performed by Validate.
+func BuiltinRules() []RuleInfo {
+	rules := make([]RuleInfo, len(builtinRules))
+	copy(rules, builtinRules)
+	return rules
+}
+
+// Name returns the short kebab-case name of the code, such as
+// "name-too-long", or empty string for an unknown code.
+func (c Code) Name() string {
+	for _, r := range builtinRules {
+		if r.Code == c {
+			return r.Name
+		}
+	}
+	return ""
+}
+
+// severityOf returns the default severity of code.
+func severityOf(code Code) Severity {
+	for _, r := range builtinRules {
+		if r.Code == code {
+			return r.Severity
+		}
+	}
+	return SeverityError
+}
+
+// codeOf returns the code of the check whose sentinel error err wraps,
+// or empty string if none does.
+func codeOf(err error) Code {
+	for _, r := range builtinRules {
+		if errors.Is(err, r.err) {
+			return r.Code
+		}
+	}
+	return ""
+}
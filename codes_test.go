@@ -0,0 +1,162 @@
+package agentskills
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// validationCodes returns the codes of all findings in err.
+func validationCodes(t *testing.T, err error) []Code {
+	t.Helper()
+	var verrs *ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("expected *ValidationErrors, got %v", err)
+	}
+	var codes []Code
+	for _, e := range verrs.Errors {
+		var ve *ValidationError
+		if !errors.As(e, &ve) {
+			t.Fatalf("expected *ValidationError, got %T: %v", e, e)
+		}
+		codes = append(codes, ve.Code)
+	}
+	return codes
+}
+
+func containsCode(codes []Code, code Code) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func TestBuiltinRules_Unique(t *testing.T) {
+	codes := map[Code]bool{}
+	names := map[string]bool{}
+	for _, r := range BuiltinRules() {
+		if codes[r.Code] {
+			t.Errorf("duplicate code %s", r.Code)
+		}
+		if names[r.Name] {
+			t.Errorf("duplicate name %s", r.Name)
+		}
+		codes[r.Code] = true
+		names[r.Name] = true
+
+		if !strings.HasPrefix(string(r.Code), "AS") || len(r.Code) != 5 {
+			t.Errorf("malformed code %q", r.Code)
+		}
+		if r.Description == "" {
+			t.Errorf("%s has no description", r.Code)
+		}
+		if got := r.Code.Name(); got != r.Name {
+			t.Errorf("%s.Name() = %q, want %q", r.Code, got, r.Name)
+		}
+	}
+}
+
+func TestValidate_RuleCodes(t *testing.T) {
+	tests := []struct {
+		dir     string
+		content string
+		code    Code
+	}{
+		{"a", "---\nname: " + strings.Repeat("a", 70) + "\ndescription: x\n---\n", CodeNameTooLong},
+		{"my-skill", "---\nname: My-Skill\ndescription: x\n---\n", CodeNameNotLowercase},
+		{"my-skill", "---\nname: my-skill-\ndescription: x\n---\n", CodeNameHyphenBoundary},
+		{"my-skill", "---\nname: my--skill\ndescription: x\n---\n", CodeNameConsecutiveHyphens},
+		{"my-skill", "---\nname: my_skill\ndescription: x\n---\n", CodeNameInvalidCharacters},
+		{"my-skill", "---\nname: other-skill\ndescription: x\n---\n", CodeNameDirectoryMismatch},
+		{"my-skill", "---\ndescription: x\n---\n", CodeNameMissing},
+		{"my-skill", "---\nname: \"\"\ndescription: x\n---\n", CodeNameEmpty},
+		{"my-skill", "---\nname: my-skill\n---\n", CodeDescriptionMissing},
+		{"my-skill", "---\nname: my-skill\ndescription: \"\"\n---\n", CodeDescriptionEmpty},
+		{"my-skill", "---\nname: my-skill\ndescription: " + strings.Repeat("x", 1100) + "\n---\n", CodeDescriptionTooLong},
+		{"my-skill", "---\nname: my-skill\ndescription: x\ncompatibility: " + strings.Repeat("x", 550) + "\n---\n", CodeCompatibilityTooLong},
+		{"my-skill", "---\nname: my-skill\ndescription: x\nowner: me\n---\n", CodeUnexpectedField},
+		{"my-skill", "---\nname: my-skill\ndescription: x\nallowed-tools: Bash(git:*\n---\n", CodeAllowedToolsInvalid},
+		{"my-skill", "# no frontmatter\n", CodeFrontmatterMissing},
+		{"my-skill", "---\nname: my-skill\n", CodeFrontmatterUnclosed},
+		{"my-skill", "---\nname: [bad\n---\n", CodeFrontmatterInvalid},
+		{"my-skill", "---\n---\n", CodeFrontmatterNotMapping},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.code), func(t *testing.T) {
+			skillDir := filepath.Join(t.TempDir(), tt.dir)
+			if err := os.Mkdir(skillDir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			codes := validationCodes(t, Validate(skillDir))
+			if !containsCode(codes, tt.code) {
+				t.Errorf("expected code %s (%s), got %v", tt.code, tt.code.Name(), codes)
+			}
+		})
+	}
+}
+
+func TestValidate_PathRuleCodes(t *testing.T) {
+	tests := []struct {
+		path string
+		code Code
+	}{
+		{"testdata/nonexistent", CodePathNotFound},
+		{"testdata/valid-skill/SKILL.md", CodePathNotDirectory},
+		{t.TempDir(), CodeSkillMDMissing},
+	}
+
+	for _, tt := range tests {
+		codes := validationCodes(t, Validate(tt.path))
+		if len(codes) != 1 || codes[0] != tt.code {
+			t.Errorf("Validate(%q): expected [%s], got %v", tt.path, tt.code, codes)
+		}
+	}
+}
+
+func TestValidate_UnexpectedFieldPerField(t *testing.T) {
+	err := Validate("testdata/unexpected-fields")
+	var verrs *ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("expected *ValidationErrors, got %v", err)
+	}
+
+	var fields []string
+	for _, e := range verrs.Errors {
+		var ve *ValidationError
+		if errors.As(e, &ve) && ve.Code == CodeUnexpectedField {
+			fields = append(fields, ve.Field)
+			if !errors.Is(ve, ErrUnexpectedField) {
+				t.Errorf("expected ErrUnexpectedField for %s", ve.Field)
+			}
+		}
+	}
+	if !equalStrings(fields, []string{"another_bad", "unknown_field"}) {
+		t.Errorf("expected one finding per unexpected field, got %v", fields)
+	}
+}
+
+func TestValidationError_Fields(t *testing.T) {
+	err := Validate("testdata/invalid-uppercase")
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if ve.Code != CodeNameNotLowercase || ve.Field != "name" || ve.Severity != SeverityError {
+		t.Errorf("unexpected diagnostic: %+v", ve)
+	}
+	if ve.Message != "skill name 'Invalid-Uppercase' must be lowercase" {
+		t.Errorf("unexpected message: %q", ve.Message)
+	}
+	if !errors.Is(ve, ErrNameNotLowercase) {
+		t.Error("expected diagnostic to wrap ErrNameNotLowercase")
+	}
+}
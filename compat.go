@@ -0,0 +1,271 @@
+package agentskills
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Compatibility is the structured form of the compatibility field. The
+// field stays free text in general; it is structured when it consists of
+// requirements separated by semicolons, each a key, a colon and a
+// comma-separated list:
+//
+//	os: linux, darwin; arch: amd64, arm64; bin: git, jq; runtime: python >=3.11 <4, node >=18
+//
+// Keys are lower case: text starting with a capitalized key, such as
+// "Runtime: Node.js 18+" or "OS: Linux or macOS", is free text.
+//
+// A skill runs on any of the listed operating systems and architectures,
+// and needs all of the listed binaries and runtimes. Operating systems and
+// architectures use Go's names (GOOS and GOARCH), with macos, x86_64 and
+// aarch64 accepted as aliases. A runtime is a name optionally followed by
+// version constraints, all of which must hold: >=, >, <=, <, = or !=
+// followed by a dotted version.
+type Compatibility struct {
+	OS       []string
+	Arch     []string
+	Binaries []string
+	Runtimes []RuntimeRequirement
+}
+
+// RuntimeRequirement is a language runtime a skill needs, such as Python
+// 3.11 or later.
+type RuntimeRequirement struct {
+	// Name is the lower-case runtime name, such as "python" or "node".
+	Name string
+
+	// Constraints restrict the runtime version; all must hold.
+	Constraints []VersionConstraint
+}
+
+// String returns the requirement as written in the compatibility field.
+func (r RuntimeRequirement) String() string {
+	parts := []string{r.Name}
+	for _, c := range r.Constraints {
+		parts = append(parts, c.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+// VersionConstraint compares a runtime version with Version.
+type VersionConstraint struct {
+	// Op is one of ">=", ">", "<=", "<", "=" or "!=".
+	Op      string
+	Version string
+}
+
+// String returns the constraint as written, such as ">=3.11".
+func (c VersionConstraint) String() string {
+	return c.Op + c.Version
+}
+
+// Allows reports whether version satisfies the constraint. Versions are
+// compared by their dotted numeric components, missing components counting
+// as zero, so "3.11" equals "3.11.0".
+func (c VersionConstraint) Allows(version string) bool {
+	n := compareVersions(version, c.Version)
+	switch c.Op {
+	case ">=":
+		return n >= 0
+	case ">":
+		return n > 0
+	case "<=":
+		return n <= 0
+	case "<":
+		return n < 0
+	case "=":
+		return n == 0
+	case "!=":
+		return n != 0
+	}
+	return false
+}
+
+// compatibilityKeys lists the requirement keys of structured compatibility.
+var compatibilityKeys = []string{"os", "arch", "bin", "runtime"}
+
+// platformAliases maps common names to GOOS and GOARCH values.
+var platformAliases = map[string]string{
+	"macos":   "darwin",
+	"x86_64":  "amd64",
+	"aarch64": "arm64",
+}
+
+var (
+	versionPattern    = regexp.MustCompile(`^\d+(\.\d+)*$`)
+	constraintPattern = regexp.MustCompile(`^(>=|<=|!=|>|<|=)\s*([^\s<>=!]*)`)
+)
+
+// platformName returns the lower-case GOOS or GOARCH value for name,
+// resolving platformAliases.
+func platformName(name string) string {
+	name = strings.ToLower(name)
+	if alias, ok := platformAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// ParseCompatibility parses a structured compatibility field. It returns
+// an error wrapping ErrCompatibilityUnstructured if text is free text,
+// that is, does not start with a lower-case requirement key, and one
+// wrapping ErrCompatibilityInvalid if text is structured but malformed.
+func ParseCompatibility(text string) (*Compatibility, error) {
+	clauses := strings.Split(text, ";")
+	if key, _, ok := strings.Cut(clauses[0], ":"); !ok || !slices.Contains(compatibilityKeys, strings.TrimSpace(key)) {
+		return nil, ErrCompatibilityUnstructured
+	}
+
+	c := &Compatibility{}
+	for _, clause := range clauses {
+		if strings.TrimSpace(clause) == "" {
+			continue
+		}
+		key, list, ok := strings.Cut(clause, ":")
+		key = strings.TrimSpace(key)
+		if !ok || !slices.Contains(compatibilityKeys, key) {
+			return nil, detailf(ErrCompatibilityInvalid, "compatibility requirement %q must start with os:, arch:, bin: or runtime:",
+				strings.TrimSpace(clause))
+		}
+		for _, item := range strings.Split(list, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				return nil, detailf(ErrCompatibilityInvalid, "compatibility requirement %q has an empty item", strings.TrimSpace(clause))
+			}
+			switch key {
+			case "os", "arch":
+				if strings.ContainsAny(item, " \t") {
+					return nil, detailf(ErrCompatibilityInvalid, "compatibility %s %q contains spaces", key, item)
+				}
+				name := platformName(item)
+				if key == "os" {
+					c.OS = append(c.OS, name)
+				} else {
+					c.Arch = append(c.Arch, name)
+				}
+			case "bin":
+				if strings.ContainsAny(item, " \t") {
+					return nil, detailf(ErrCompatibilityInvalid, "compatibility binary %q contains spaces", item)
+				}
+				c.Binaries = append(c.Binaries, item)
+			case "runtime":
+				r, err := parseRuntimeRequirement(item)
+				if err != nil {
+					return nil, err
+				}
+				c.Runtimes = append(c.Runtimes, r)
+			}
+		}
+	}
+	return c, nil
+}
+
+// parseRuntimeRequirement parses a runtime name and its version
+// constraints, such as "python >=3.11 <4".
+func parseRuntimeRequirement(item string) (RuntimeRequirement, error) {
+	i := strings.IndexAny(item, "<>=! \t")
+	if i < 0 {
+		i = len(item)
+	}
+	r := RuntimeRequirement{Name: strings.ToLower(item[:i])}
+	if r.Name == "" {
+		return r, detailf(ErrCompatibilityInvalid, "compatibility runtime %q has no name", item)
+	}
+
+	for rest := strings.TrimSpace(item[i:]); rest != ""; {
+		m := constraintPattern.FindStringSubmatch(rest)
+		if m == nil || !versionPattern.MatchString(m[2]) {
+			return r, detailf(ErrCompatibilityInvalid, "compatibility runtime %q: %q is not a version constraint such as >=3.11", item, rest)
+		}
+		r.Constraints = append(r.Constraints, VersionConstraint{Op: m[1], Version: m[2]})
+		rest = strings.TrimSpace(rest[len(m[0]):])
+	}
+	return r, nil
+}
+
+// compareVersions compares the dotted numeric components at the start of
+// a and b, such as "3.11.4" in "Python 3.11.4" or "18.2.0" in "v18.2.0".
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < max(len(pa), len(pb)); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionPrefix matches the first dotted number in a version string.
+var versionPrefix = regexp.MustCompile(`\d+(\.\d+)*`)
+
+func versionParts(v string) []int {
+	var parts []int
+	for _, p := range strings.Split(versionPrefix.FindString(v), ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// Check returns the reasons c rules out env, or nil if a skill with c can
+// run there. Requirements on what env leaves unknown are assumed to hold.
+// The OS and architecture of env may use the same aliases as the field.
+func (c *Compatibility) Check(env *Environment) []string {
+	var reasons []string
+	if len(c.OS) > 0 && env.OS != "" && !slices.Contains(c.OS, platformName(env.OS)) {
+		reasons = append(reasons, fmt.Sprintf("requires os %s, not %s", strings.Join(c.OS, " or "), env.OS))
+	}
+	if len(c.Arch) > 0 && env.Arch != "" && !slices.Contains(c.Arch, platformName(env.Arch)) {
+		reasons = append(reasons, fmt.Sprintf("requires arch %s, not %s", strings.Join(c.Arch, " or "), env.Arch))
+	}
+	if env.Binaries != nil {
+		for _, bin := range c.Binaries {
+			if !slices.Contains(env.Binaries, bin) {
+				reasons = append(reasons, fmt.Sprintf("requires %s on PATH", bin))
+			}
+		}
+	}
+	if env.Runtimes != nil {
+		for _, r := range c.Runtimes {
+			version, ok := env.Runtimes[r.Name]
+			if !ok {
+				reasons = append(reasons, fmt.Sprintf("requires %s, which is not installed", r))
+				continue
+			}
+			for _, constraint := range r.Constraints {
+				if !constraint.Allows(version) {
+					reasons = append(reasons, fmt.Sprintf("requires %s, found %s %s", r, r.Name, version))
+					break
+				}
+			}
+		}
+	}
+	return reasons
+}
+
+// CheckCompatibility parses a compatibility field and returns the reasons
+// it rules out env, as Compatibility.Check does. Free text and malformed
+// fields return the error of ParseCompatibility.
+func CheckCompatibility(compatibility string, env *Environment) ([]string, error) {
+	c, err := ParseCompatibility(compatibility)
+	if err != nil {
+		return nil, err
+	}
+	return c.Check(env), nil
+}
@@ -0,0 +1,163 @@
+package agentskills
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseCompatibility(t *testing.T) {
+	got, err := ParseCompatibility("os: Linux, macos; arch: x86_64; bin: git, jq; runtime: python >=3.11 <4, node>=18, go;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Compatibility{
+		OS:       []string{"linux", "darwin"},
+		Arch:     []string{"amd64"},
+		Binaries: []string{"git", "jq"},
+		Runtimes: []RuntimeRequirement{
+			{Name: "python", Constraints: []VersionConstraint{{">=", "3.11"}, {"<", "4"}}},
+			{Name: "node", Constraints: []VersionConstraint{{">=", "18"}}},
+			{Name: "go"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if s := got.Runtimes[0].String(); s != "python >=3.11 <4" {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+func TestParseCompatibility_Errors(t *testing.T) {
+	for _, text := range []string{"", "Requires Python 3.11+", "Works on Linux: tested on Ubuntu", "Runtime: Node.js 18+", "OS: Linux or macOS"} {
+		if _, err := ParseCompatibility(text); !errors.Is(err, ErrCompatibilityUnstructured) {
+			t.Errorf("ParseCompatibility(%q) = %v, want free text", text, err)
+		}
+	}
+	for _, text := range []string{
+		"os: linux; gpu: cuda",
+		"os: linux; Arch: amd64",
+		"os: linux,",
+		"os: linux (tested)",
+		"bin: git lfs",
+		"runtime: python 3.11",
+		"runtime: python >=3.x",
+		"runtime: >=3.11",
+		"runtime: python >= 3.11 ~4",
+	} {
+		if _, err := ParseCompatibility(text); !errors.Is(err, ErrCompatibilityInvalid) {
+			t.Errorf("ParseCompatibility(%q) = %v, want invalid", text, err)
+		}
+	}
+}
+
+func TestVersionConstraint_Allows(t *testing.T) {
+	tests := []struct {
+		constraint VersionConstraint
+		version    string
+		want       bool
+	}{
+		{VersionConstraint{">=", "3.11"}, "3.11.4", true},
+		{VersionConstraint{">=", "3.11"}, "3.9.18", false},
+		{VersionConstraint{">", "3.11"}, "3.11.0", false},
+		{VersionConstraint{"<", "4"}, "3.12", true},
+		{VersionConstraint{"<=", "18"}, "v18.0.0", true},
+		{VersionConstraint{"=", "1.22"}, "go1.22", true},
+		{VersionConstraint{"!=", "1.22"}, "1.22.1", true},
+	}
+	for _, tt := range tests {
+		if got := tt.constraint.Allows(tt.version); got != tt.want {
+			t.Errorf("%s allows %s = %v, want %v", tt.constraint, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	env := &Environment{OS: "linux", Arch: "arm64", Binaries: []string{"git"}, Runtimes: map[string]string{"python": "3.9.18"}}
+	tests := []struct {
+		compat string
+		want   []string
+	}{
+		{"os: linux, darwin; bin: git", nil},
+		{"os: windows; arch: amd64", []string{"requires os windows, not linux", "requires arch amd64, not arm64"}},
+		{"bin: git, jq", []string{"requires jq on PATH"}},
+		{"runtime: python >=3.11, node", []string{"requires python >=3.11, found python 3.9.18", "requires node, which is not installed"}},
+		{"runtime: python >=3.8 <3.10", nil},
+	}
+	for _, tt := range tests {
+		got, err := CheckCompatibility(tt.compat, env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("CheckCompatibility(%q) = %q, want %q", tt.compat, got, tt.want)
+		}
+	}
+
+	// The environment may use the same aliases as the field.
+	if got, _ := CheckCompatibility("os: darwin; arch: arm64", &Environment{OS: "macOS", Arch: "aarch64"}); got != nil {
+		t.Errorf("aliased environment ruled out a skill: %q", got)
+	}
+
+	// Unknown parts of the environment are not checked.
+	if got, _ := CheckCompatibility("os: windows; bin: jq; runtime: node", &Environment{}); got != nil {
+		t.Errorf("empty environment ruled out a skill: %q", got)
+	}
+}
+
+func TestProbeEnvironment(t *testing.T) {
+	env := ProbeEnvironment(context.Background())
+	if env.OS != runtime.GOOS || env.Arch != runtime.GOARCH {
+		t.Errorf("got %s/%s, want %s/%s", env.OS, env.Arch, runtime.GOOS, runtime.GOARCH)
+	}
+	if !slices.IsSorted(env.Binaries) {
+		t.Error("binaries are not sorted")
+	}
+	if slices.Contains(env.Binaries, "go") && env.Runtimes["go"] == "" {
+		t.Error("go is on PATH but its version was not probed")
+	}
+}
+
+func TestRenderPrompt_Environment(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a/SKILL.md": {Data: []byte("---\nname: a\ndescription: Linux only\ncompatibility: \"os: linux\"\n---\n")},
+		"b/SKILL.md": {Data: []byte("---\nname: b\ndescription: Windows only\ncompatibility: \"os: windows; bin: powershell\"\n---\n")},
+		"c/SKILL.md": {Data: []byte("---\nname: c\ndescription: Anywhere\ncompatibility: Works everywhere\n---\n")},
+	}
+	result, err := GeneratePromptFS(fsys, []string{"a", "b", "c"}, PromptOptions{
+		Renderer:    TextRenderer{},
+		Environment: &Environment{OS: "linux", Binaries: []string{"git"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "a: Linux only [a/SKILL.md]\nc: Anywhere [c/SKILL.md]"; result.Prompt != want {
+		t.Errorf("got:\n%s\nwant:\n%s", result.Prompt, want)
+	}
+	want := []PromptExclusion{{Name: "b", Location: "b/SKILL.md", Reasons: []string{"requires os windows, not linux", "requires powershell on PATH"}}}
+	if !reflect.DeepEqual(result.Exclusions, want) {
+		t.Errorf("exclusions = %+v, want %+v", result.Exclusions, want)
+	}
+}
+
+func TestCheck_CompatibilityInvalid(t *testing.T) {
+	fsys := fstest.MapFS{
+		"my-skill/SKILL.md": {Data: []byte("---\nname: my-skill\ndescription: x\nlicense: MIT\ncompatibility: \"os: linux; gpu: cuda\"\n---\nBody\n")},
+	}
+	result := CheckFS(fsys, "my-skill", ValidateOptions{})
+	var codes []Code
+	for _, w := range result.BySeverity(SeverityWarning) {
+		var ve *ValidationError
+		if errors.As(w, &ve) {
+			codes = append(codes, ve.Code)
+		}
+	}
+	if result.HasErrors() || !containsCode(codes, CodeCompatibilityInvalid) {
+		t.Errorf("expected a %s warning, got %v", CodeCompatibilityInvalid, result)
+	}
+}
@@ -0,0 +1,442 @@
+package agentskills
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// ConfigFileName is the name of the validation config file looked up by
+// FindConfig.
+const ConfigFileName = ".agentskills.yaml"
+
+// Config is a repository-level validation config, usually read from a
+// .agentskills.yaml file:
+//
+//	strict: false
+//	rules:
+//	  license-missing: off       # or "on", "error", "warning", "info"
+//	  AS104: error               # rules are named by name or code
+//	  description-too-long:
+//	    severity: warning
+//	    max: 512                 # other keys are rule options
+//	allowed-fields: [owner]
+//	limits:
+//	  compatibility: 300
+//	overrides:
+//	  - paths: ["experimental/**"]
+//	    rules:
+//	      unexpected-field: warning
+type Config struct {
+	// Strict promotes warnings to errors.
+	Strict bool `yaml:"strict"`
+
+	ConfigSettings `yaml:",inline"`
+
+	// Overrides adjust the settings for skills below matching paths.
+	// Later overrides take precedence over earlier ones.
+	Overrides []ConfigOverride `yaml:"overrides"`
+
+	dir string // directory that override paths are relative to
+}
+
+// ConfigSettings are the rule settings of a Config or ConfigOverride.
+type ConfigSettings struct {
+	// Rules configures rules by code or name.
+	Rules map[string]RuleSetting `yaml:"rules"`
+
+	// AllowedFields lists frontmatter fields accepted in addition to the
+	// ones defined by the specification.
+	AllowedFields []string `yaml:"allowed-fields"`
+
+	// Limits overrides the length limits of the specification. A limit
+	// conflicts with the "max" or "min" option of the same rule.
+	Limits Limits `yaml:"limits"`
+
+	// keyPos records where the keys of Rules and Limits appear in the
+	// config file, as "rules.<key>" and "limits.<key>".
+	keyPos map[string]Position
+}
+
+// ConfigOverride applies settings to the skills matching Paths.
+type ConfigOverride struct {
+	// Paths are gitignore-style patterns, relative to the directory of the
+	// config file, matched against skill directories.
+	Paths []string `yaml:"paths"`
+
+	ConfigSettings `yaml:",inline"`
+}
+
+// Limits overrides length limits. Zero values keep the defaults.
+type Limits struct {
+	Name           int `yaml:"name"`            // default MaxSkillNameLength
+	Description    int `yaml:"description"`     // default MaxDescriptionLength
+	DescriptionMin int `yaml:"description-min"` // default MinDescriptionLength
+	Compatibility  int `yaml:"compatibility"`   // default MaxCompatibilityLength
+}
+
+// RuleSetting configures one rule. In YAML it is either a scalar ("off",
+// "on", or a severity) or a mapping with optional "enabled" and "severity"
+// keys, where every other key is a rule option.
+type RuleSetting struct {
+	// Disabled turns the rule off.
+	Disabled bool
+
+	// Severity overrides the rule's severity unless empty.
+	Severity string
+
+	// Options holds rule-specific settings such as "max".
+	Options map[string]any
+}
+
+// UnmarshalYAML implements yaml.InterfaceUnmarshaler.
+func (r *RuleSetting) UnmarshalYAML(unmarshal func(any) error) error {
+	var raw any
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	switch v := raw.(type) {
+	case bool:
+		r.Disabled = !v
+	case string:
+		switch v {
+		case "off":
+			r.Disabled = true
+		case "on":
+		default:
+			r.Severity = v
+		}
+	case map[string]any:
+		for key, val := range v {
+			switch key {
+			case "enabled":
+				enabled, ok := val.(bool)
+				if !ok {
+					return fmt.Errorf("rule setting 'enabled' must be a boolean")
+				}
+				r.Disabled = !enabled
+			case "severity":
+				sev, ok := val.(string)
+				if !ok {
+					return fmt.Errorf("rule setting 'severity' must be a string")
+				}
+				r.Severity = sev
+			default:
+				if r.Options == nil {
+					r.Options = make(map[string]any)
+				}
+				r.Options[key] = val
+			}
+		}
+	default:
+		return fmt.Errorf("rule setting must be a string or a mapping, got %T", raw)
+	}
+
+	if r.Severity != "" {
+		if _, err := ParseSeverity(r.Severity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadConfig reads the config file at path. Override paths are relative
+// to the directory containing the file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &ParseError{Path: path, Err: fmt.Errorf("%w: %w", ErrInvalidConfig, err)}
+	}
+	cfg, err := parseConfig(data, path)
+	if err != nil {
+		return nil, err
+	}
+	if abs, err := filepath.Abs(filepath.Dir(path)); err == nil {
+		cfg.dir = filepath.ToSlash(abs)
+	}
+	return cfg, nil
+}
+
+// parseConfig parses the content of the config file file.
+func parseConfig(data []byte, file string) (*Config, error) {
+	cfg := &Config{}
+	if err := yaml.UnmarshalWithOptions(data, cfg, yaml.DisallowUnknownField()); err != nil {
+		return nil, &ParseError{Path: file, Err: fmt.Errorf("%w: %w", ErrInvalidConfig, err)}
+	}
+	indexConfig(cfg, data, file)
+	return cfg, nil
+}
+
+// indexConfig records the positions of the rule and limit keys of cfg,
+// parsed from data, so that errors in applying them point into the file.
+func indexConfig(cfg *Config, data []byte, file string) {
+	f, err := parser.ParseBytes(data, 0)
+	if err != nil || len(f.Docs) == 0 {
+		return
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	keyPos := func(mv *ast.MappingValueNode) (string, Position) {
+		tk := mv.Key.GetToken()
+		if tk == nil {
+			return "", Position{}
+		}
+		pos := Position{File: file, Line: tk.Position.Line, Column: tk.Position.Column}
+		if pos.Line < 1 || pos.Line > len(lines) {
+			return tk.Value, Position{File: file}
+		}
+		for _, l := range lines[:pos.Line-1] {
+			pos.Offset += len(l)
+		}
+		// The parser counts columns in runes.
+		col := 1
+		for i := 1; i < pos.Column && col <= len(lines[pos.Line-1]); i++ {
+			_, size := utf8.DecodeRuneInString(lines[pos.Line-1][col-1:])
+			col += size
+		}
+		pos.Column = col
+		pos.Offset += col - 1
+		pos.EndOffset = pos.Offset + len(tk.Value)
+		return tk.Value, pos
+	}
+	index := func(cs *ConfigSettings, entries []*ast.MappingValueNode) {
+		for _, mv := range entries {
+			section, _ := keyPos(mv)
+			if section != "rules" && section != "limits" {
+				continue
+			}
+			for _, entry := range mappingValues(mv.Value) {
+				key, pos := keyPos(entry)
+				if cs.keyPos == nil {
+					cs.keyPos = make(map[string]Position)
+				}
+				cs.keyPos[section+"."+key] = pos
+			}
+		}
+	}
+
+	top := mappingValues(f.Docs[0].Body)
+	index(&cfg.ConfigSettings, top)
+	for _, mv := range top {
+		seq, ok := mv.Value.(*ast.SequenceNode)
+		if key, _ := keyPos(mv); key != "overrides" || !ok {
+			continue
+		}
+		for i, item := range seq.Values {
+			if i < len(cfg.Overrides) {
+				index(&cfg.Overrides[i].ConfigSettings, mappingValues(item))
+			}
+		}
+	}
+}
+
+// FindConfig looks for a config file in skillDir and each of its parent
+// directories. It returns the path of the nearest one, or empty string if
+// there is none.
+func FindConfig(skillDir string) (string, error) {
+	dir, err := filepath.Abs(skillDir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		file := filepath.Join(dir, ConfigFileName)
+		_, err := os.Stat(file)
+		if err == nil {
+			return file, nil
+		}
+		if errors.Is(err, fs.ErrPermission) {
+			return "", err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// findConfig loads the config file nearest to the skill directory, or
+// returns nil if there is none. Within an fs.FS the search stops at the
+// root of the file system.
+func (s skillFS) findConfig() (*Config, error) {
+	if s.os {
+		file, err := FindConfig(s.base)
+		if err != nil {
+			return nil, &ParseError{Path: s.base, Err: fmt.Errorf("%w: %w", ErrInvalidConfig, err)}
+		}
+		if file == "" {
+			return nil, nil
+		}
+		return LoadConfig(file)
+	}
+
+	dir := s.dir
+	for {
+		file := path.Join(dir, ConfigFileName)
+		data, err := fs.ReadFile(s.fsys, file)
+		if err == nil {
+			cfg, err := parseConfig(data, file)
+			if err != nil {
+				return nil, err
+			}
+			cfg.dir = dir
+			return cfg, nil
+		}
+		if errors.Is(err, fs.ErrPermission) {
+			return nil, &ParseError{Path: file, Err: fmt.Errorf("%w: %w", ErrInvalidConfig, err)}
+		}
+		if dir == "." {
+			return nil, nil
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+// relDir returns the slash-separated path of the skill directory relative
+// to dir, or empty string if the skill is not below dir. An empty dir
+// stands for the working directory, or the root of an fs.FS.
+func (s skillFS) relDir(dir string) string {
+	skillDir := s.dir
+	if s.os {
+		abs, err := filepath.Abs(s.base)
+		if err != nil {
+			return ""
+		}
+		skillDir = filepath.ToSlash(abs)
+	}
+	if dir == "" && s.os {
+		wd, err := os.Getwd()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.ToSlash(wd)
+	}
+	if dir == "" || dir == "." {
+		return skillDir
+	}
+	if skillDir == dir {
+		return "."
+	}
+	rel, ok := strings.CutPrefix(skillDir, strings.TrimSuffix(dir, "/")+"/")
+	if !ok {
+		return ""
+	}
+	return rel
+}
+
+// apply configures v for the skill directory at rel, relative to the
+// directory of the config file.
+func (c *Config) apply(v *Validator, rel string) error {
+	v.Strict = v.Strict || c.Strict
+	if err := c.ConfigSettings.apply(v); err != nil {
+		return err
+	}
+	for _, o := range c.Overrides {
+		if !o.matches(rel) {
+			continue
+		}
+		if err := o.ConfigSettings.apply(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matches reports whether the override applies to the skill directory at
+// rel. As in .gitignore, a pattern matching a directory also covers
+// everything below it.
+func (o *ConfigOverride) matches(rel string) bool {
+	if rel == "" {
+		return false
+	}
+	m := newIgnoreMatcher(o.Paths)
+	for p := rel; ; p = path.Dir(p) {
+		if m.match(p, true) {
+			return true
+		}
+		if !strings.Contains(p, "/") {
+			return false
+		}
+	}
+}
+
+func (cs *ConfigSettings) apply(v *Validator) error {
+	configured := make(map[Code]string, len(cs.Rules))
+	for _, key := range slices.Sorted(maps.Keys(cs.Rules)) {
+		setting := cs.Rules[key]
+		r := v.lookup(key)
+		if r == nil {
+			return cs.errorAt("rules."+key, fmt.Errorf("%w: unknown rule %q", ErrInvalidConfig, key))
+		}
+		code := r.Info().Code
+		if other, ok := configured[code]; ok {
+			return cs.errorAt("rules."+key, fmt.Errorf("%w: rule %s is configured twice, as %q and %q", ErrInvalidConfig, code, other, key))
+		}
+		configured[code] = key
+		if setting.Disabled {
+			v.Disable(code)
+		} else {
+			v.Enable(code)
+		}
+		if setting.Severity != "" {
+			sev, err := ParseSeverity(setting.Severity)
+			if err != nil {
+				return cs.errorAt("rules."+key, fmt.Errorf("%w: rule %s: %w", ErrInvalidConfig, key, err))
+			}
+			v.SetSeverity(code, sev)
+		}
+		for opt, val := range setting.Options {
+			v.SetOption(code, opt, val)
+		}
+	}
+
+	if len(cs.AllowedFields) > 0 {
+		allowed := stringsOf(v.configFor(CodeUnexpectedField).options["allowed"])
+		v.SetOption(CodeUnexpectedField, "allowed", append(allowed, cs.AllowedFields...))
+	}
+
+	for _, l := range []struct {
+		key    string
+		code   Code
+		option string
+		limit  int
+	}{
+		{"name", CodeNameTooLong, "max", cs.Limits.Name},
+		{"description", CodeDescriptionTooLong, "max", cs.Limits.Description},
+		{"description-min", CodeDescriptionTooShort, "min", cs.Limits.DescriptionMin},
+		{"compatibility", CodeCompatibilityTooLong, "max", cs.Limits.Compatibility},
+	} {
+		if l.limit <= 0 {
+			continue
+		}
+		if key, ok := configured[l.code]; ok {
+			if _, ok := cs.Rules[key].Options[l.option]; ok {
+				return cs.errorAt("limits."+l.key, fmt.Errorf("%w: limits.%s conflicts with the %s option of rule %s; set only one",
+					ErrInvalidConfig, l.key, l.option, key))
+			}
+		}
+		v.SetOption(l.code, l.option, l.limit)
+	}
+	return nil
+}
+
+// errorAt returns err located at the config key, when its position is
+// known.
+func (cs *ConfigSettings) errorAt(key string, err error) error {
+	pos, ok := cs.keyPos[key]
+	if !ok {
+		return err
+	}
+	return &ParseError{Path: pos.File, Pos: pos, Err: err}
+}
@@ -0,0 +1,211 @@
+package agentskills
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func writeConfig(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, ConfigFileName), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, `strict: true
+rules:
+  license-missing: off
+  AS104: error
+  description-too-long:
+    severity: warning
+    max: 512
+allowed-fields: [owner]
+limits:
+  name: 32
+overrides:
+  - paths: ["experimental/**"]
+    rules:
+      unexpected-field: warning
+`)
+
+	cfg, err := LoadConfig(filepath.Join(dir, ConfigFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Strict || cfg.Limits.Name != 32 || len(cfg.AllowedFields) != 1 || len(cfg.Overrides) != 1 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if !cfg.Rules["license-missing"].Disabled {
+		t.Error("expected license-missing to be disabled")
+	}
+	if got := cfg.Rules["AS104"].Severity; got != "error" {
+		t.Errorf("AS104 severity = %q, want error", got)
+	}
+	if got := cfg.Rules["description-too-long"]; got.Severity != "warning" || got.Options["max"] != uint64(512) {
+		t.Errorf("unexpected description-too-long setting: %+v", got)
+	}
+	if got := cfg.Overrides[0].Rules["unexpected-field"].Severity; got != "warning" {
+		t.Errorf("override severity = %q, want warning", got)
+	}
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	for _, content := range []string{
+		"rules:\n  license-missing: fatal\n",
+		"rules:\n  license-missing: [a]\n",
+		"unknown-key: true\n",
+	} {
+		dir := t.TempDir()
+		writeConfig(t, dir, content)
+		if _, err := LoadConfig(filepath.Join(dir, ConfigFileName)); err == nil {
+			t.Errorf("expected error for config %q", content)
+		}
+	}
+}
+
+func TestFindConfig(t *testing.T) {
+	root := t.TempDir()
+	skillDir := filepath.Join(root, "skills", "my-skill")
+	writeSkill(t, skillDir, "my-skill")
+
+	if file, err := FindConfig(skillDir); err != nil || file != "" {
+		t.Errorf("FindConfig without config = %q, %v", file, err)
+	}
+
+	writeConfig(t, root, "strict: true\n")
+	file, err := FindConfig(skillDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, ConfigFileName); file != want {
+		t.Errorf("FindConfig = %q, want %q", file, want)
+	}
+}
+
+func TestValidate_UsesConfig(t *testing.T) {
+	root := t.TempDir()
+	skillDir := filepath.Join(root, "skills", "my-skill")
+	if err := os.MkdirAll(skillDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: my-skill\ndescription: " + strings.Repeat("a", 40) + "\nowner: docs\n---\nBody\n"
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if codes := validationCodes(t, Validate(skillDir)); !containsCode(codes, CodeUnexpectedField) {
+		t.Fatalf("expected unexpected-field without config, got %v", codes)
+	}
+
+	writeConfig(t, root, "rules:\n  license-missing: off\nallowed-fields: [owner]\n")
+	if result := Check(skillDir, ValidateOptions{}); len(result.Errors) != 0 {
+		t.Errorf("expected config to silence all findings, got: %v", result)
+	}
+
+	writeConfig(t, root, "allowed-fields: [owner]\nlimits:\n  description: 30\n")
+	codes := validationCodes(t, Validate(skillDir))
+	if !containsCode(codes, CodeDescriptionTooLong) {
+		t.Errorf("expected lowered limit to report %s, got %v", CodeDescriptionTooLong, codes)
+	}
+}
+
+func TestValidate_ConfigOverrides(t *testing.T) {
+	root := t.TempDir()
+	stable := filepath.Join(root, "stable", "my-skill")
+	experimental := filepath.Join(root, "experimental", "my-skill")
+	writeSkill(t, stable, "my-skill")
+	writeSkill(t, experimental, "my-skill")
+
+	writeConfig(t, root, `rules:
+  license-missing: error
+overrides:
+  - paths: ["experimental/"]
+    rules:
+      license-missing: off
+`)
+
+	if err := Validate(stable); err == nil {
+		t.Error("expected license-missing error outside the override")
+	}
+	if err := Validate(experimental); err != nil {
+		t.Errorf("expected override to disable license-missing, got: %v", err)
+	}
+}
+
+func TestValidate_ConfigErrors(t *testing.T) {
+	for _, content := range []string{
+		"rules: [\n",
+		"rules:\n  no-such-rule: off\n",
+		"rules:\n  AS601: error\n  license-missing: off\n",
+		"rules:\n  description-too-long:\n    max: 512\nlimits:\n  description: 256\n",
+	} {
+		root := t.TempDir()
+		skillDir := filepath.Join(root, "my-skill")
+		writeSkill(t, skillDir, "my-skill")
+		writeConfig(t, root, content)
+
+		codes := validationCodes(t, Validate(skillDir))
+		if len(codes) != 1 || codes[0] != CodeConfigInvalid {
+			t.Errorf("config %q: expected %s, got %v", content, CodeConfigInvalid, codes)
+		}
+	}
+}
+
+func TestValidate_ConfigErrorPosition(t *testing.T) {
+	root := t.TempDir()
+	skillDir := filepath.Join(root, "my-skill")
+	writeSkill(t, skillDir, "my-skill")
+
+	for _, tc := range []struct {
+		content string
+		line    int
+		column  int
+	}{
+		{"strict: false\nrules:\n  license-missing: off\n  no-such-rule: off\n", 4, 3},
+		{"overrides:\n  - paths: [\"*\"]\n    rules:\n      no-such-rule: off\n", 4, 7},
+		{"rules:\n  description-too-long: {max: 512}\nlimits:\n  description: 256\n", 4, 3},
+	} {
+		writeConfig(t, root, tc.content)
+		result := Check(skillDir, ValidateOptions{})
+		if len(result.Errors) != 1 {
+			t.Fatalf("config %q: expected one finding, got %v", tc.content, result)
+		}
+		var ve *ValidationError
+		if !errors.As(result.Errors[0], &ve) || ve.Code != CodeConfigInvalid {
+			t.Fatalf("config %q: expected %s, got %v", tc.content, CodeConfigInvalid, result.Errors[0])
+		}
+		if want := filepath.Join(root, ConfigFileName); ve.Pos.File != want || ve.Pos.Line != tc.line || ve.Pos.Column != tc.column {
+			t.Errorf("config %q: position = %s, want %s:%d:%d", tc.content, ve.Pos, want, tc.line, tc.column)
+		}
+	}
+}
+
+func TestCheckFS_DiscoversConfig(t *testing.T) {
+	fsys := fstest.MapFS{
+		ConfigFileName:             &fstest.MapFile{Data: []byte("rules:\n  license-missing: error\n")},
+		"skills/my-skill/SKILL.md": &fstest.MapFile{Data: []byte("---\nname: my-skill\ndescription: Does things when asked to.\n---\nBody\n")},
+	}
+	codes := validationCodes(t, ValidateFS(fsys, "skills/my-skill"))
+	if len(codes) != 1 || codes[0] != CodeLicenseMissing {
+		t.Errorf("expected only %s, got %v", CodeLicenseMissing, codes)
+	}
+}
+
+func TestValidator_ExplicitConfig(t *testing.T) {
+	v := NewValidator()
+	v.Config = &Config{ConfigSettings: ConfigSettings{
+		Rules: map[string]RuleSetting{"name-not-lowercase": {Severity: "warning"}},
+	}}
+	v.Disable(CodeNameDirectoryMismatch)
+
+	result := v.Check("testdata/invalid-uppercase")
+	if result.HasErrors() {
+		t.Errorf("expected explicit config to downgrade findings, got: %v", result)
+	}
+}
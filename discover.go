@@ -0,0 +1,168 @@
+package agentskills
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DiscoverOptions controls how Discover walks a directory tree.
+type DiscoverOptions struct {
+	// MaxDepth limits how many directory levels below the root are searched.
+	// The root itself is depth 0. Zero or negative means no limit.
+	MaxDepth int
+
+	// Ignore lists .gitignore-style patterns matched against paths relative
+	// to the root. Ignored directories are not descended into.
+	Ignore []string
+
+	// FollowSymlinks makes Discover descend into symlinked directories.
+	// Symlink cycles are detected and each directory is visited once.
+	FollowSymlinks bool
+}
+
+// DiscoveredSkill describes a skill directory found by Discover.
+type DiscoveredSkill struct {
+	// Dir is the skill directory, joined onto the root passed to Discover.
+	Dir string
+
+	// SkillMD is the path to the SKILL.md (or skill.md) file.
+	SkillMD string
+
+	// Properties holds the parsed frontmatter, or nil if parsing failed.
+	Properties *SkillProperties
+
+	// Err is the error returned by ReadProperties, if any. For a
+	// directory that could not be searched, Err is the error reading it
+	// and SkillMD is empty.
+	Err error
+}
+
+// Discover walks root and returns every directory containing a SKILL.md
+// file, in lexical order. A skill directory is not searched for nested
+// skills, and ".git" directories are always skipped.
+//
+// Each skill is parsed with ReadProperties; parse failures, and
+// subdirectories that cannot be read, are reported in DiscoveredSkill.Err
+// rather than aborting the walk. The returned error is non-nil only when
+// root itself cannot be read.
+func Discover(root string, opts DiscoverOptions) ([]DiscoveredSkill, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%w: %s", ErrPathNotDirectory, root)
+	}
+
+	w := &walker{
+		opts:    opts,
+		ignore:  newIgnoreMatcher(opts.Ignore),
+		visited: make(map[string]bool),
+	}
+	if err := w.walk(root, "", 0); err != nil {
+		return nil, err
+	}
+	return w.skills, nil
+}
+
+// DiscoverAll runs Discover on each root and concatenates the results.
+// Skill directories reachable from more than one root are reported once.
+func DiscoverAll(roots []string, opts DiscoverOptions) ([]DiscoveredSkill, error) {
+	var all []DiscoveredSkill
+	seen := make(map[string]bool)
+	for _, root := range roots {
+		skills, err := Discover(root, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range skills {
+			key := s.Dir
+			if abs, err := filepath.Abs(s.Dir); err == nil {
+				key = abs
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			all = append(all, s)
+		}
+	}
+	return all, nil
+}
+
+// walker holds the state of a single Discover call.
+type walker struct {
+	opts    DiscoverOptions
+	ignore  ignoreMatcher
+	visited map[string]bool
+	skills  []DiscoveredSkill
+}
+
+// walk visits dir, whose slash-separated path relative to the root is rel.
+func (w *walker) walk(dir, rel string, depth int) error {
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		if w.visited[resolved] {
+			return nil
+		}
+		w.visited[resolved] = true
+	}
+
+	if skillMD := findSkillMD(dir); skillMD != "" {
+		props, err := ReadProperties(dir)
+		w.skills = append(w.skills, DiscoveredSkill{
+			Dir:        dir,
+			SkillMD:    skillMD,
+			Properties: props,
+			Err:        err,
+		})
+		return nil
+	}
+
+	if w.opts.MaxDepth > 0 && depth >= w.opts.MaxDepth {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if depth == 0 {
+			return err
+		}
+		// Report the unreadable directory and search its siblings.
+		w.skills = append(w.skills, DiscoveredSkill{Dir: dir, Err: err})
+		return nil
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		isDir := entry.IsDir()
+		if entry.Type()&os.ModeSymlink != 0 {
+			if !w.opts.FollowSymlinks {
+				continue
+			}
+			info, err := os.Stat(filepath.Join(dir, name))
+			if err != nil {
+				// Dangling symlink
+				continue
+			}
+			isDir = info.IsDir()
+		}
+		if !isDir || name == ".git" {
+			continue
+		}
+
+		childRel := name
+		if rel != "" {
+			childRel = rel + "/" + name
+		}
+		if w.ignore.match(childRel, true) {
+			continue
+		}
+
+		if err := w.walk(filepath.Join(dir, name), childRel, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
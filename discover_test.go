@@ -0,0 +1,231 @@
+package agentskills
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+// writeSkill creates dir (and parents) containing a SKILL.md for name.
+func writeSkill(t *testing.T, dir, name string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: " + name + "\ndescription: Test skill " + name + "\n---\nBody\n"
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func discoveredNames(skills []DiscoveredSkill) []string {
+	var names []string
+	for _, s := range skills {
+		if s.Properties != nil {
+			names = append(names, s.Properties.Name)
+		}
+	}
+	return names
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDiscover_FindsNestedSkills(t *testing.T) {
+	root := t.TempDir()
+	writeSkill(t, filepath.Join(root, "alpha"), "alpha")
+	writeSkill(t, filepath.Join(root, "team", "beta"), "beta")
+	writeSkill(t, filepath.Join(root, "team", "sub", "gamma"), "gamma")
+	if err := os.MkdirAll(filepath.Join(root, "empty"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	skills, err := Discover(root, DiscoverOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"alpha", "beta", "gamma"}
+	if got := discoveredNames(skills); !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if skills[0].SkillMD != filepath.Join(root, "alpha", "SKILL.md") {
+		t.Errorf("unexpected SKILL.md path: %s", skills[0].SkillMD)
+	}
+}
+
+func TestDiscover_DoesNotDescendIntoSkills(t *testing.T) {
+	root := t.TempDir()
+	writeSkill(t, filepath.Join(root, "outer"), "outer")
+	writeSkill(t, filepath.Join(root, "outer", "inner"), "inner")
+
+	skills, err := Discover(root, DiscoverOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := discoveredNames(skills); !slices.Equal(got, []string{"outer"}) {
+		t.Errorf("expected only outer skill, got %v", got)
+	}
+}
+
+func TestDiscover_MaxDepth(t *testing.T) {
+	root := t.TempDir()
+	writeSkill(t, filepath.Join(root, "a"), "a")
+	writeSkill(t, filepath.Join(root, "x", "b"), "b")
+	writeSkill(t, filepath.Join(root, "x", "y", "c"), "c")
+
+	skills, err := Discover(root, DiscoverOptions{MaxDepth: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := discoveredNames(skills); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("expected [a b], got %v", got)
+	}
+}
+
+func TestDiscover_IgnorePatterns(t *testing.T) {
+	root := t.TempDir()
+	writeSkill(t, filepath.Join(root, "keep"), "keep")
+	writeSkill(t, filepath.Join(root, "node_modules", "dep"), "dep")
+	writeSkill(t, filepath.Join(root, "vendor", "skip"), "skip")
+	writeSkill(t, filepath.Join(root, "vendor", "wanted"), "wanted")
+	writeSkill(t, filepath.Join(root, "deep", "build", "out"), "out")
+
+	skills, err := Discover(root, DiscoverOptions{
+		Ignore: []string{
+			"# comment",
+			"node_modules/",
+			"/vendor/*",
+			"!/vendor/wanted",
+			"**/build",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := discoveredNames(skills); !slices.Equal(got, []string{"keep", "wanted"}) {
+		t.Errorf("expected [keep wanted], got %v", got)
+	}
+}
+
+func TestDiscover_Symlinks(t *testing.T) {
+	root := t.TempDir()
+	other := t.TempDir()
+	writeSkill(t, filepath.Join(other, "linked"), "linked")
+	if err := os.Symlink(other, filepath.Join(root, "link")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	// A cycle back to the root must not loop forever.
+	if err := os.Symlink(root, filepath.Join(other, "cycle")); err != nil {
+		t.Fatal(err)
+	}
+
+	skills, err := Discover(root, DiscoverOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(skills) != 0 {
+		t.Errorf("expected symlinks to be skipped by default, got %v", discoveredNames(skills))
+	}
+
+	skills, err = Discover(root, DiscoverOptions{FollowSymlinks: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := discoveredNames(skills); !slices.Equal(got, []string{"linked"}) {
+		t.Errorf("expected [linked], got %v", got)
+	}
+}
+
+func TestDiscover_ReportsParseErrors(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "broken")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("no frontmatter"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	skills, err := Discover(root, DiscoverOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(skills) != 1 {
+		t.Fatalf("expected 1 skill, got %d", len(skills))
+	}
+	if skills[0].Err == nil || skills[0].Properties != nil {
+		t.Errorf("expected parse error, got %+v", skills[0])
+	}
+}
+
+func TestDiscover_RootErrors(t *testing.T) {
+	if _, err := Discover("testdata/nonexistent", DiscoverOptions{}); err == nil {
+		t.Error("expected error for nonexistent root")
+	}
+	if _, err := Discover("testdata/valid-skill/SKILL.md", DiscoverOptions{}); err == nil {
+		t.Error("expected error for file root")
+	}
+}
+
+func TestDiscoverAll_DeduplicatesOverlappingRoots(t *testing.T) {
+	root := t.TempDir()
+	writeSkill(t, filepath.Join(root, "team", "one"), "one")
+	writeSkill(t, filepath.Join(root, "two"), "two")
+
+	skills, err := DiscoverAll([]string{root, filepath.Join(root, "team")}, DiscoverOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := discoveredNames(skills); !slices.Equal(got, []string{"one", "two"}) {
+		t.Errorf("expected [one two], got %v", got)
+	}
+}
+
+func TestDiscover_Testdata(t *testing.T) {
+	skills, err := Discover("testdata", DiscoverOptions{MaxDepth: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(skills) < 5 {
+		t.Errorf("expected testdata skills to be discovered, got %d", len(skills))
+	}
+}
+
+func TestDiscover_UnreadableSubdirectory(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Getuid() == 0 {
+		t.Skip("directory permissions are not enforced")
+	}
+	root := t.TempDir()
+	writeSkill(t, filepath.Join(root, "b"), "b")
+	locked := filepath.Join(root, "a")
+	if err := os.Mkdir(locked, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(locked, 0); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chmod(locked, 0o755) })
+
+	skills, err := Discover(root, DiscoverOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(skills) != 2 || skills[0].Dir != locked || skills[0].Err == nil || skills[0].SkillMD != "" {
+		t.Fatalf("expected the unreadable directory to be reported, got %+v", skills)
+	}
+	if got := discoveredNames(skills); !slices.Equal(got, []string{"b"}) {
+		t.Errorf("expected [b], got %v", got)
+	}
+}
@@ -14,6 +14,26 @@
 //	}
 //	fmt.Printf("Skill: %s - %s\n", props.Name, props.Description)
 //
+// Use [Load] to read the complete [Skill], including the markdown
+// instructions that follow the frontmatter and the bundled resource files:
+//
+//	skill, err := agentskills.Load("path/to/my-skill")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(skill.Body)
+//
+// Use [Parse] or [ParseBytes] when the SKILL.md content does not come from a
+// directory, such as an HTTP upload or a database row:
+//
+//	result, err := agentskills.Parse(r, agentskills.ParseOptions{})
+//	if err != nil {
+//	    log.Fatal(err) // no parseable frontmatter
+//	}
+//	if err := result.Err(); err != nil {
+//	    log.Print(err) // validation problems
+//	}
+//
 // # Validating Skills
 //
 // Use [Validate] to check a skill directory against the Agent Skills specification:
@@ -32,6 +52,65 @@
 //   - Description: max 1024 chars
 //   - No unexpected frontmatter fields
 //
+// Each problem is a [*ValidationError] with a stable [Code] such as "AS001"
+// (name-too-long), so findings can be suppressed and counted without
+// matching message text; [BuiltinRules] documents every code. Its Pos field
+// holds the file, line, column and byte range of the offending field, so
+// editors and pre-commit hooks can point at the exact location.
+// [*ParseError] carries the same position information for malformed
+// frontmatter.
+//
+// Findings have a [Severity]. Validate only fails on errors; [Check] also
+// returns warnings (for example a missing license) and infos, and its
+// Strict option promotes warnings to errors.
+//
+// To disable rules, change their severity or limits, or add rules of your
+// own, configure a [Validator]:
+//
+//	v := agentskills.NewValidator()
+//	v.Disable(agentskills.CodeLicenseMissing)
+//	err := v.Register(agentskills.NewRule(info, func(ctx *agentskills.RuleContext) {
+//	    if ctx.Properties.License != "MIT" {
+//	        ctx.Reportf("license", "license must be MIT")
+//	    }
+//	}))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = v.Validate("path/to/my-skill")
+//
+// [Validate] and [Check] also apply the nearest .agentskills.yaml file found
+// by walking up from the skill directory; see [Config] for its format.
+//
+// # Reading from an fs.FS
+//
+// [ReadPropertiesFS], [ValidateFS] and [ToPromptFS] accept any [io/fs.FS], so
+// skills embedded in a binary, stored in a zip archive or held in memory can be
+// used without touching disk:
+//
+//	//go:embed skills
+//	var skillsFS embed.FS
+//
+//	if err := agentskills.ValidateFS(skillsFS, "skills/my-skill"); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// # Discovering Skills
+//
+// Use [Discover] to find every skill directory below a root, for example in a
+// monorepo:
+//
+//	skills, err := agentskills.Discover("skills", agentskills.DiscoverOptions{
+//	    MaxDepth: 4,
+//	    Ignore:   []string{"node_modules/", "/archive"},
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, s := range skills {
+//	    fmt.Println(s.Dir, s.Err)
+//	}
+//
 // # Generating Agent Prompts
 //
 // Use [ToPrompt] to generate the <available_skills> XML block for agent prompts:
@@ -0,0 +1,488 @@
+package agentskills
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// FrontmatterEditor changes individual fields of SKILL.md frontmatter. Lines
+// it does not touch, including comments, key order, scalar styles and line
+// endings, are kept byte for byte. Edited values are written in the
+// canonical style of Write, and a comment on the same line as an edited
+// value is kept.
+//
+// A FrontmatterEditor is passed to the function given to Edit or EditBytes.
+type FrontmatterEditor struct {
+	lines []string // frontmatter lines, each with its line terminator
+	nl    string   // line terminator for inserted lines
+}
+
+// Edit reads the SKILL.md in skillDir, calls fn to change its frontmatter
+// and writes the file back if anything changed. If fn returns an error, the
+// file is left unchanged and the error is returned.
+//
+// Example:
+//
+//	err := agentskills.Edit("skills/pdf-reader", func(e *agentskills.FrontmatterEditor) error {
+//	    if err := e.SetMetadata("version", "1.1"); err != nil {
+//	        return err
+//	    }
+//	    return e.Set("allowed-tools", "Bash(pdftotext:*) Read")
+//	})
+func Edit(skillDir string, fn func(*FrontmatterEditor) error) error {
+	path := findSkillMD(skillDir)
+	if path == "" {
+		return &ParseError{Path: skillDir, Err: ErrSkillMDNotFound}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return &ParseError{Path: path, Err: err}
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return &ParseError{Path: path, Err: err}
+	}
+
+	out, err := EditBytes(content, fn)
+	if err != nil {
+		var pe *ParseError
+		if errors.As(err, &pe) && pe.Path == "" {
+			pe.Path = path
+		}
+		return err
+	}
+	if bytes.Equal(out, content) {
+		return nil
+	}
+	return os.WriteFile(path, out, info.Mode().Perm())
+}
+
+// EditBytes is like Edit but operates on SKILL.md content and returns the
+// edited content. The markdown body is never changed.
+func EditBytes(content []byte, fn func(*FrontmatterEditor) error) ([]byte, error) {
+	if _, err := parseDocument(content, ""); err != nil {
+		return nil, err
+	}
+
+	s := string(content)
+	bom := ""
+	if strings.HasPrefix(s, "\ufeff") {
+		bom, s = "\ufeff", s[len("\ufeff"):]
+	}
+	lines := splitLines(s)
+	end := 1
+	for end < len(lines) && !isDelimiterLine(lineText(lines[end]), "---") && !isDelimiterLine(lineText(lines[end]), "...") {
+		end++
+	}
+
+	e := &FrontmatterEditor{lines: slices.Clone(lines[1:end]), nl: lineTerminator(lines[0])}
+	if err := fn(e); err != nil {
+		return nil, err
+	}
+
+	out := bom + lines[0] + strings.Join(e.lines, "") + strings.Join(lines[end:], "")
+	if _, err := parseDocument([]byte(out), ""); err != nil {
+		return nil, fmt.Errorf("edited frontmatter is invalid: %w", err)
+	}
+	return []byte(out), nil
+}
+
+// Get returns the value of the top-level field as written in the
+// frontmatter. It reports false if the field is absent or not a scalar.
+func (e *FrontmatterEditor) Get(field string) (string, bool) {
+	entries, err := e.entries()
+	if err != nil {
+		return "", false
+	}
+	if mv := findEntry(entries, field); mv != nil {
+		return scalarText(mv.Value)
+	}
+	return "", false
+}
+
+// Set sets the top-level field to value. An existing field is changed in
+// place; a new one is inserted in specification order after the known
+// fields that precede it, or after the last field. Use SetMetadata for
+// entries of the metadata field. Set does not validate the value.
+func (e *FrontmatterEditor) Set(field, value string) error {
+	if field == "metadata" {
+		return errors.New("use SetMetadata to edit the metadata field")
+	}
+	return e.apply("set field "+field, func() error {
+		entries, err := e.entries()
+		if err != nil {
+			return err
+		}
+		e.set(entries, "", field, value, e.insertLine(entries, field))
+		return nil
+	}, func() bool {
+		v, ok := e.Get(field)
+		return ok && v == value
+	})
+}
+
+// Delete removes the top-level field and its value. Comments on the lines
+// above it are kept. Deleting an absent field does nothing.
+func (e *FrontmatterEditor) Delete(field string) error {
+	return e.apply("delete field "+field, func() error {
+		entries, err := e.entries()
+		if err != nil {
+			return err
+		}
+		if mv := findEntry(entries, field); mv != nil {
+			start := keyLine(mv)
+			e.replace(start, e.entryEnd(start), nil)
+		}
+		return nil
+	}, func() bool {
+		entries, err := e.entries()
+		return err == nil && findEntry(entries, field) == nil
+	})
+}
+
+// Metadata returns the value of key in the metadata field as written in
+// the frontmatter. It reports false if the key is absent or not a scalar.
+func (e *FrontmatterEditor) Metadata(key string) (string, bool) {
+	entries, err := e.entries()
+	if err != nil {
+		return "", false
+	}
+	if mv := findEntry(entries, "metadata"); mv != nil {
+		if child := findEntry(mappingValues(mv.Value), key); child != nil {
+			return scalarText(child.Value)
+		}
+	}
+	return "", false
+}
+
+// SetMetadata sets key in the metadata field to value, creating the field
+// if needed. A new key is added after the existing ones. Metadata written in
+// flow style ("metadata: {a: b}") is first rewritten in block style.
+func (e *FrontmatterEditor) SetMetadata(key, value string) error {
+	return e.apply("set metadata."+key, func() error {
+		return e.setMetadata(key, value)
+	}, func() bool {
+		v, ok := e.Metadata(key)
+		return ok && v == value
+	})
+}
+
+func (e *FrontmatterEditor) setMetadata(key, value string) error {
+	mv, children, err := e.metadata(true)
+	if err != nil {
+		return err
+	}
+	if mv == nil {
+		entries, err := e.entries()
+		if err != nil {
+			return err
+		}
+		var fw frontmatterWriter
+		fw.line("metadata:")
+		fw.scalar("  ", key, value)
+		at := e.insertLine(entries, "metadata")
+		e.replace(at, at, splitRendered(fw.String()))
+		return nil
+	}
+
+	start := keyLine(mv)
+	indent := lineIndent(e.lines[start]) + "  "
+	at := start + 1
+	if len(children) > 0 {
+		indent = lineIndent(e.lines[keyLine(children[0])])
+		at = e.entryEnd(keyLine(children[len(children)-1]))
+	}
+	e.set(children, indent, key, value, at)
+	return nil
+}
+
+// DeleteMetadata removes key from the metadata field. The metadata field is
+// removed when its last key is. Deleting an absent key does nothing.
+func (e *FrontmatterEditor) DeleteMetadata(key string) error {
+	return e.apply("delete metadata."+key, func() error {
+		return e.deleteMetadata(key)
+	}, func() bool {
+		_, ok := e.Metadata(key)
+		return !ok
+	})
+}
+
+func (e *FrontmatterEditor) deleteMetadata(key string) error {
+	mv, children, err := e.metadata(false)
+	if err != nil || mv == nil || findEntry(children, key) == nil {
+		return err
+	}
+	if isFlowMapping(mv.Value) {
+		if mv, children, err = e.metadata(true); err != nil {
+			return err
+		}
+	}
+
+	if len(children) == 1 {
+		start := keyLine(mv)
+		e.replace(start, e.entryEnd(start), nil)
+		return nil
+	}
+	start := keyLine(findEntry(children, key))
+	e.replace(start, e.entryEnd(start), nil)
+	return nil
+}
+
+// apply runs edit and checks the result with ok. If either fails, the
+// frontmatter is restored and an error returned, so that layouts the
+// line-based editing cannot handle are never silently corrupted.
+func (e *FrontmatterEditor) apply(what string, edit func() error, ok func() bool) error {
+	saved := slices.Clone(e.lines)
+	if err := edit(); err != nil {
+		e.lines = saved
+		return err
+	}
+	if !ok() {
+		e.lines = saved
+		return fmt.Errorf("cannot %s: unsupported frontmatter layout", what)
+	}
+	return nil
+}
+
+// entries parses the current frontmatter and returns its top-level entries.
+func (e *FrontmatterEditor) entries() ([]*ast.MappingValueNode, error) {
+	var sb strings.Builder
+	for _, l := range e.lines {
+		sb.WriteString(lineText(l))
+		sb.WriteString("\n")
+	}
+	file, err := parser.ParseBytes([]byte(sb.String()), parser.ParseComments)
+	if err != nil {
+		return nil, &ParseError{Err: fmt.Errorf("%w: %w", ErrInvalidYAML, err)}
+	}
+	if len(file.Docs) == 0 || file.Docs[0].Body == nil {
+		return nil, nil
+	}
+	if isFlowMapping(file.Docs[0].Body) {
+		return nil, errors.New("frontmatter written in flow style cannot be edited")
+	}
+	return mappingValues(file.Docs[0].Body), nil
+}
+
+// metadata returns the metadata entry and its entries, or nil if the field
+// is absent. With block, metadata in flow style is rewritten in block style
+// first.
+func (e *FrontmatterEditor) metadata(block bool) (*ast.MappingValueNode, []*ast.MappingValueNode, error) {
+	entries, err := e.entries()
+	if err != nil {
+		return nil, nil, err
+	}
+	mv := findEntry(entries, "metadata")
+	if mv == nil {
+		return nil, nil, nil
+	}
+	if _, isNull := mv.Value.(*ast.NullNode); !isNull && len(mappingValues(mv.Value)) == 0 && !isFlowMapping(mv.Value) {
+		return nil, nil, errors.New("field 'metadata' is not a mapping")
+	}
+	if !block || !isFlowMapping(mv.Value) {
+		return mv, mappingValues(mv.Value), nil
+	}
+
+	var fw frontmatterWriter
+	fw.line("metadata:")
+	for _, child := range mappingValues(mv.Value) {
+		if text, ok := scalarText(child.Value); ok {
+			fw.scalar("  ", keyText(child.Key), text)
+		} else if err := fw.node("  ", keyText(child.Key), child.Value); err != nil {
+			return nil, nil, err
+		}
+	}
+	start := keyLine(mv)
+	e.replace(start, e.entryEnd(start), splitRendered(fw.String()))
+	return e.metadata(false)
+}
+
+// set sets key to value among entries, the entries of a block mapping whose
+// keys are indented by indent. A new key is inserted at line at.
+func (e *FrontmatterEditor) set(entries []*ast.MappingValueNode, indent, key, value string, at int) {
+	var fw frontmatterWriter
+	fw.scalar(indent, key, value)
+	rendered := splitRendered(fw.String())
+
+	mv := findEntry(entries, key)
+	if mv == nil {
+		e.replace(at, at, rendered)
+		return
+	}
+	if text, ok := scalarText(mv.Value); ok && text == value {
+		return
+	}
+	start := keyLine(mv)
+	end := e.entryEnd(start)
+	if end == start+1 && len(rendered) == 1 {
+		rendered[0] += trailingComment(lineText(e.lines[start]), start, mv.Value)
+	}
+	e.replace(start, end, rendered)
+}
+
+// insertLine returns the line at which a new top-level field is inserted:
+// after the closest field that precedes it in specification order, before
+// the closest one that follows it, or after the last field.
+func (e *FrontmatterEditor) insertLine(entries []*ast.MappingValueNode, field string) int {
+	order := append(slices.Clone(canonicalOrder), "metadata")
+	rank := slices.Index(order, field)
+	if rank >= 0 {
+		var before, after *ast.MappingValueNode
+		beforeRank, afterRank := -1, len(order)
+		for _, mv := range entries {
+			r := slices.Index(order, keyText(mv.Key))
+			switch {
+			case r < 0:
+			case r < rank && r > beforeRank:
+				before, beforeRank = mv, r
+			case r > rank && r < afterRank:
+				after, afterRank = mv, r
+			}
+		}
+		if before != nil {
+			return e.entryEnd(keyLine(before))
+		}
+		if after != nil {
+			return keyLine(after)
+		}
+	}
+	if len(entries) == 0 {
+		return len(e.lines)
+	}
+	return e.entryEnd(keyLine(entries[len(entries)-1]))
+}
+
+// entryEnd returns the line after the last line of the mapping entry whose
+// key is on line start: the lines indented deeper than the key, and block
+// sequence items at the key's indentation. Blank and comment lines that
+// follow the entry are not part of it.
+func (e *FrontmatterEditor) entryEnd(start int) int {
+	indent := len(lineIndent(e.lines[start]))
+	end := start + 1
+	for i := start + 1; i < len(e.lines); i++ {
+		text := lineText(e.lines[i])
+		trimmed := strings.TrimLeft(text, " \t")
+		n := len(text) - len(trimmed)
+		switch {
+		case trimmed == "":
+		case n > indent:
+			end = i + 1
+		case strings.HasPrefix(trimmed, "#"):
+		case n == indent && (trimmed == "-" || strings.HasPrefix(trimmed, "- ")):
+			end = i + 1
+		default:
+			return end
+		}
+	}
+	return end
+}
+
+// replace replaces lines [start, end) with the rendered lines.
+func (e *FrontmatterEditor) replace(start, end int, rendered []string) {
+	lines := make([]string, len(rendered))
+	for i, l := range rendered {
+		lines[i] = l + e.nl
+	}
+	e.lines = slices.Replace(e.lines, start, end, lines...)
+}
+
+// findEntry returns the entry of entries with the given key, or nil.
+func findEntry(entries []*ast.MappingValueNode, key string) *ast.MappingValueNode {
+	for _, mv := range entries {
+		if keyText(mv.Key) == key {
+			return mv
+		}
+	}
+	return nil
+}
+
+// keyLine returns the 0-based frontmatter line of the key of mv.
+func keyLine(mv *ast.MappingValueNode) int {
+	return mv.Key.GetToken().Position.Line - 1
+}
+
+// isFlowMapping reports whether node is a mapping written in flow style.
+func isFlowMapping(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.AnchorNode:
+		return isFlowMapping(n.Value)
+	case *ast.TagNode:
+		return isFlowMapping(n.Value)
+	case *ast.MappingNode:
+		return n.IsFlowStyle
+	}
+	return false
+}
+
+// trailingComment returns the comment, with the whitespace before it, that
+// follows value on line text, the frontmatter line numbered line.
+func trailingComment(text string, line int, value ast.Node) string {
+	group := value.GetComment()
+	if group == nil || len(group.Comments) == 0 {
+		return ""
+	}
+	tk := group.Comments[0].GetToken()
+	if tk.Position.Line-1 != line {
+		return ""
+	}
+	i := 0
+	for col := 1; col < tk.Position.Column && i < len(text); col++ {
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+	for i > 0 && (text[i-1] == ' ' || text[i-1] == '\t') {
+		i--
+	}
+	return text[i:]
+}
+
+// splitLines splits s into lines, each ending with its "\n", "\r\n" or "\r"
+// terminator. The last line has no terminator if s does not end with one.
+func splitLines(s string) []string {
+	var lines []string
+	for s != "" {
+		i := strings.IndexAny(s, "\r\n")
+		switch {
+		case i < 0:
+			i = len(s)
+		case strings.HasPrefix(s[i:], "\r\n"):
+			i += 2
+		default:
+			i++
+		}
+		lines = append(lines, s[:i])
+		s = s[i:]
+	}
+	return lines
+}
+
+// lineText returns line without its terminator.
+func lineText(line string) string {
+	return strings.TrimRight(line, "\r\n")
+}
+
+// lineTerminator returns the terminator of line, defaulting to "\n".
+func lineTerminator(line string) string {
+	if t := line[len(lineText(line)):]; t != "" {
+		return t
+	}
+	return "\n"
+}
+
+// lineIndent returns the leading spaces of line.
+func lineIndent(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " "))]
+}
+
+// splitRendered splits the output of a frontmatterWriter into lines.
+func splitRendered(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,242 @@
+package agentskills
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const editInput = `---
+# Maintained by the docs team.
+name: pdf-reader
+description: 'Reads PDF files.'   # keep it short
+allowed-tools: Bash(pdftotext:*)
+compatibility: Requires poppler-utils
+
+# Release information.
+metadata:
+  author: Docs Team
+  version: "1.0"  # bumped by CI
+x-owner: docs
+---
+# PDF Reader
+
+Body is not touched: name: foo
+`
+
+func TestEditBytes(t *testing.T) {
+	out, err := EditBytes([]byte(editInput), func(e *FrontmatterEditor) error {
+		if v, ok := e.Metadata("version"); !ok || v != "1.0" {
+			t.Errorf("Metadata(version) = %q, %v", v, ok)
+		}
+		if v, ok := e.Get("description"); !ok || v != "Reads PDF files." {
+			t.Errorf("Get(description) = %q, %v", v, ok)
+		}
+		for _, step := range []error{
+			e.SetMetadata("version", "1.1"),
+			e.SetMetadata("reviewed", "yes"),
+			e.Set("allowed-tools", "Bash(pdftotext:*) Read"),
+			e.Set("license", "MIT"),
+			e.Delete("compatibility"),
+			e.Set("name", "pdf-reader"),
+		} {
+			if step != nil {
+				return step
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `---
+# Maintained by the docs team.
+name: pdf-reader
+description: 'Reads PDF files.'   # keep it short
+license: MIT
+allowed-tools: Bash(pdftotext:*) Read
+
+# Release information.
+metadata:
+  author: Docs Team
+  version: "1.1"  # bumped by CI
+  reviewed: "yes"
+x-owner: docs
+---
+# PDF Reader
+
+Body is not touched: name: foo
+`
+	if string(out) != want {
+		t.Errorf("EditBytes:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestEditBytes_Unchanged(t *testing.T) {
+	out, err := EditBytes([]byte(editInput), func(e *FrontmatterEditor) error {
+		if err := e.Set("description", "Reads PDF files."); err != nil {
+			return err
+		}
+		if err := e.DeleteMetadata("missing"); err != nil {
+			return err
+		}
+		return e.SetMetadata("version", "1.0")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != editInput {
+		t.Errorf("expected content unchanged, got:\n%s", out)
+	}
+}
+
+func TestEditBytes_LineEndings(t *testing.T) {
+	in := "\ufeff---\r\nname: my-skill\r\ndescription: Does things.\r\n---\r\nBody\r\n"
+	out, err := EditBytes([]byte(in), func(e *FrontmatterEditor) error {
+		return e.SetMetadata("version", "2")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "\ufeff---\r\nname: my-skill\r\ndescription: Does things.\r\nmetadata:\r\n  version: \"2\"\r\n---\r\nBody\r\n"
+	if string(out) != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestEditBytes_Metadata(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		edit func(e *FrontmatterEditor) error
+		want string
+	}{
+		{
+			name: "flow style",
+			in:   "name: x\nmetadata: {b: 1, a: two}\ndescription: d\n",
+			edit: func(e *FrontmatterEditor) error { return e.SetMetadata("c", "3") },
+			want: "name: x\nmetadata:\n  b: \"1\"\n  a: two\n  c: \"3\"\ndescription: d\n",
+		},
+		{
+			name: "empty",
+			in:   "name: x\ndescription: d\nmetadata:\n",
+			edit: func(e *FrontmatterEditor) error { return e.SetMetadata("a", "b") },
+			want: "name: x\ndescription: d\nmetadata:\n  a: b\n",
+		},
+		{
+			name: "delete last key",
+			in:   "name: x\nmetadata:\n    a: b\ndescription: d\n",
+			edit: func(e *FrontmatterEditor) error { return e.DeleteMetadata("a") },
+			want: "name: x\ndescription: d\n",
+		},
+		{
+			name: "custom indentation",
+			in:   "name: x\ndescription: d\nmetadata:\n    a: |\n      multi\n      line\n    b: c\n",
+			edit: func(e *FrontmatterEditor) error {
+				if err := e.SetMetadata("a", "one line"); err != nil {
+					return err
+				}
+				return e.SetMetadata("d", "e")
+			},
+			want: "name: x\ndescription: d\nmetadata:\n    a: one line\n    b: c\n    d: e\n",
+		},
+		{
+			name: "sequence value",
+			in:   "name: x\nextra:\n- 1\n- 2\ndescription: d\n",
+			edit: func(e *FrontmatterEditor) error { return e.Delete("extra") },
+			want: "name: x\ndescription: d\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := EditBytes([]byte("---\n"+tt.in+"---\n"), tt.edit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "---\n" + tt.want + "---\n"; string(out) != want {
+				t.Errorf("got:\n%s\nwant:\n%s", out, want)
+			}
+		})
+	}
+}
+
+func TestEditBytes_Errors(t *testing.T) {
+	if _, err := EditBytes([]byte("no frontmatter"), func(*FrontmatterEditor) error { return nil }); !errors.Is(err, ErrMissingFrontmatter) {
+		t.Errorf("expected ErrMissingFrontmatter, got %v", err)
+	}
+
+	content := []byte("---\nname: x\ndescription: d\nmetadata: text\n---\n")
+	if _, err := EditBytes(content, func(e *FrontmatterEditor) error { return e.SetMetadata("a", "b") }); err == nil {
+		t.Error("expected error for non-mapping metadata")
+	}
+	if _, err := EditBytes(content, func(e *FrontmatterEditor) error { return e.Set("metadata", "b") }); err == nil {
+		t.Error("expected error for Set(metadata)")
+	}
+}
+
+func TestEdit(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my-skill")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "SKILL.md")
+	original := "---\nname: my-skill\ndescription: Does things.\n---\nBody\n"
+	if err := os.WriteFile(file, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	errStop := errors.New("stop")
+	err := Edit(dir, func(e *FrontmatterEditor) error {
+		e.Set("license", "MIT")
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("expected callback error, got %v", err)
+	}
+	if content, _ := os.ReadFile(file); string(content) != original {
+		t.Errorf("file changed despite error:\n%s", content)
+	}
+
+	if err := Edit(dir, func(e *FrontmatterEditor) error { return e.Set("license", "MIT") }); err != nil {
+		t.Fatal(err)
+	}
+	props, err := ReadProperties(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if props.License != "MIT" {
+		t.Errorf("license = %q, want MIT", props.License)
+	}
+
+	if err := Edit(t.TempDir(), func(*FrontmatterEditor) error { return nil }); !errors.Is(err, ErrSkillMDNotFound) {
+		t.Errorf("expected ErrSkillMDNotFound, got %v", err)
+	}
+}
+
+func FuzzEditBytes(f *testing.F) {
+	f.Add(editInput)
+	f.Add("---\nname: x\ndescription: d\nmetadata: {a: 1}\n---\n")
+	f.Add("---\r\nname: x\r\nmetadata:\r\n  version: |\r\n    1\r\n---\r\n")
+	f.Add("---\r!00000 0\r00000000: #00000000000000000000\n---  ")
+
+	f.Fuzz(func(t *testing.T, content string) {
+		out, err := EditBytes([]byte(content), func(e *FrontmatterEditor) error {
+			if err := e.SetMetadata("version", "2.0"); err != nil {
+				return err
+			}
+			return e.Set("license", "MIT")
+		})
+		if err != nil {
+			return
+		}
+		props, err := ParseBytes(out, ParseOptions{})
+		if err != nil {
+			t.Fatalf("edited content does not parse: %v\n%q", err, out)
+		}
+		if props.Properties.License != "MIT" || props.Properties.Metadata["version"] != "2.0" {
+			t.Fatalf("edit not applied: %+v\n%q", props.Properties, out)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package agentskills
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// Environment describes where an agent runs, for checking skills'
+// structured compatibility. Build one by hand to describe a remote machine
+// or container, or use ProbeEnvironment for the local machine. Fields left
+// empty or nil are unknown, and requirements on them are assumed to hold.
+type Environment struct {
+	// OS and Arch use Go's names, such as "linux" and "amd64", or the
+	// aliases macos, x86_64 and aarch64.
+	OS   string
+	Arch string
+
+	// Binaries lists the executables on PATH, by name without extension.
+	Binaries []string
+
+	// Runtimes maps lower-case runtime names, such as "python" or "node",
+	// to their installed versions.
+	Runtimes map[string]string
+}
+
+// runtimeProbes lists the commands ProbeEnvironment runs to find runtime
+// versions, in order of preference for each runtime.
+var runtimeProbes = []struct {
+	name string
+	args []string
+}{
+	{"python", []string{"python3", "--version"}},
+	{"python", []string{"python", "--version"}},
+	{"node", []string{"node", "--version"}},
+	{"deno", []string{"deno", "--version"}},
+	{"bun", []string{"bun", "--version"}},
+	{"go", []string{"go", "version"}},
+	{"ruby", []string{"ruby", "--version"}},
+	{"java", []string{"java", "-version"}},
+	{"dotnet", []string{"dotnet", "--version"}},
+}
+
+// ProbeEnvironment describes the local machine: its OS and architecture,
+// the executables on PATH, and the versions of the python, node, deno,
+// bun, go, ruby, java and dotnet runtimes that are installed. Runtimes are
+// probed by running their version commands, which ctx can cancel.
+func ProbeEnvironment(ctx context.Context) *Environment {
+	env := &Environment{
+		OS:       runtime.GOOS,
+		Arch:     runtime.GOARCH,
+		Binaries: pathBinaries(),
+		Runtimes: make(map[string]string),
+	}
+	for _, p := range runtimeProbes {
+		if _, ok := env.Runtimes[p.name]; ok || !slices.Contains(env.Binaries, p.args[0]) {
+			continue
+		}
+		out, err := exec.CommandContext(ctx, p.args[0], p.args[1:]...).CombinedOutput()
+		if err != nil {
+			continue
+		}
+		if version := versionPrefix.FindString(string(out)); version != "" {
+			env.Runtimes[p.name] = version
+		}
+	}
+	return env
+}
+
+// pathBinaries returns the names of the executables in the PATH
+// directories, sorted and without duplicates. On Windows, the extensions
+// of PATHEXT are removed.
+func pathBinaries() []string {
+	var exts []string
+	if runtime.GOOS == "windows" {
+		exts = strings.Split(strings.ToLower(os.Getenv("PATHEXT")), ";")
+	}
+	var names []string
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if exts != nil {
+				ext := strings.ToLower(filepath.Ext(name))
+				if ext == "" || !slices.Contains(exts, ext) {
+					continue
+				}
+				names = append(names, strings.ToLower(strings.TrimSuffix(name, filepath.Ext(name))))
+				continue
+			}
+			// Stat rather than e.Info, to follow the symlinks that many
+			// package managers install.
+			info, err := os.Stat(filepath.Join(dir, name))
+			if err != nil || info.IsDir() || info.Mode().Perm()&0o111 == 0 {
+				continue
+			}
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
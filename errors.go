@@ -24,17 +24,63 @@ var (
 	ErrNameInvalidChars      = errors.New("skill name contains invalid characters")
 	ErrPathNotExist          = errors.New("path does not exist")
 	ErrPathNotDirectory      = errors.New("path is not a directory")
+	ErrSkillMDUnreadable     = errors.New("SKILL.md cannot be read")
+	ErrNameTooLong           = errors.New("skill name exceeds character limit")
+	ErrNameDirectoryMismatch = errors.New("directory name must match skill name")
+	ErrDescriptionTooLong    = errors.New("description exceeds character limit")
+	ErrCompatibilityTooLong  = errors.New("compatibility exceeds character limit")
+	ErrCompatibilityInvalid  = errors.New("invalid structured compatibility")
+	ErrUnexpectedField       = errors.New("unexpected field in frontmatter")
+	ErrAllowedToolsInvalid   = errors.New("invalid allowed-tools")
+	ErrDescriptionTooShort   = errors.New("description is too short")
+	ErrSkillMDLowercase      = errors.New("skill file should be named SKILL.md")
+	ErrLicenseMissing        = errors.New("missing recommended field in frontmatter: license")
+	ErrBodyEmpty             = errors.New("SKILL.md has no instructions after the frontmatter")
+	ErrInvalidConfig         = errors.New("invalid validation config")
+
+	// ErrCompatibilityUnstructured is returned by ParseCompatibility for a
+	// free-text compatibility field.
+	ErrCompatibilityUnstructured = errors.New("compatibility is free text")
 )
 
+// detailedError is a specific message for a sentinel error, so checks can
+// report the offending value while errors.Is still matches the sentinel.
+type detailedError struct {
+	msg string
+	err error
+}
+
+// detailf returns an error with the formatted message that wraps sentinel.
+func detailf(sentinel error, format string, args ...any) error {
+	return &detailedError{msg: fmt.Sprintf(format, args...), err: sentinel}
+}
+
+func (e *detailedError) Error() string {
+	return e.msg
+}
+
+func (e *detailedError) Unwrap() error {
+	return e.err
+}
+
 // ParseError indicates SKILL.md parsing failed.
 type ParseError struct {
 	Path string
+	Pos  Position
 	Err  error
 }
 
 func (e *ParseError) Error() string {
-	if e.Path != "" {
-		return fmt.Sprintf("%s: %v", e.Path, e.Err)
+	loc := e.Path
+	if e.Pos.IsValid() {
+		pos := e.Pos
+		if pos.File == "" {
+			pos.File = e.Path
+		}
+		loc = pos.String()
+	}
+	if loc != "" {
+		return fmt.Sprintf("%s: %v", loc, e.Err)
 	}
 	return e.Err.Error()
 }
@@ -44,10 +90,31 @@ func (e *ParseError) Unwrap() error {
 }
 
 // ValidationError represents a single validation problem.
+// Code identifies the check that produced it (see [BuiltinRules]) and Pos
+// locates the problem in the SKILL.md file when it is known. Fix, if set,
+// is a suggested correction that ApplyFixes can apply.
 type ValidationError struct {
-	Field   string
-	Message string
-	Err     error
+	Code     Code
+	Severity Severity
+	Field    string
+	Message  string
+	Err      error
+	Pos      Position
+	Fix      *Fix
+}
+
+// newValidationError returns a ValidationError for err, coded by the
+// sentinel error it wraps and with that check's default severity.
+func newValidationError(field string, pos Position, err error) *ValidationError {
+	code := codeOf(err)
+	return &ValidationError{
+		Code:     code,
+		Severity: severityOf(code),
+		Field:    field,
+		Message:  err.Error(),
+		Err:      err,
+		Pos:      pos,
+	}
 }
 
 func (e *ValidationError) Error() string {
@@ -75,20 +142,53 @@ func (e *ValidationErrors) Error() string {
 		return "validation failed"
 	}
 	if len(e.Errors) == 1 {
-		return e.Errors[0].Error()
+		return withSeverity(e.Errors[0])
 	}
 
 	var sb strings.Builder
-	sb.WriteString("validation failed with ")
-	sb.WriteString(fmt.Sprintf("%d errors:\n", len(e.Errors)))
+	if e.HasErrors() {
+		sb.WriteString("validation failed with ")
+	} else {
+		sb.WriteString("validation found ")
+	}
+	var counts []string
+	for _, sev := range []Severity{SeverityError, SeverityWarning, SeverityInfo} {
+		switch n := len(e.BySeverity(sev)); n {
+		case 0:
+		case 1:
+			counts = append(counts, "1 "+sev.String())
+		default:
+			counts = append(counts, fmt.Sprintf("%d %ss", n, sev))
+		}
+	}
+	sb.WriteString(strings.Join(counts, ", ") + ":\n")
 	for _, err := range e.Errors {
 		sb.WriteString("  - ")
-		sb.WriteString(err.Error())
+		sb.WriteString(withSeverity(err))
 		sb.WriteString("\n")
 	}
 	return strings.TrimRight(sb.String(), "\n")
 }
 
+// withSeverity returns the message of err, prefixed with its severity when
+// it is not an error.
+func withSeverity(err error) string {
+	if sev := severityOfError(err); sev != SeverityError {
+		return sev.String() + ": " + err.Error()
+	}
+	return err.Error()
+}
+
+// severityOfError returns the severity of a *ValidationError, and
+// SeverityError for any other error.
+func severityOfError(err error) Severity {
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		return ve.Severity
+	}
+	return SeverityError
+}
+
 // Unwrap returns the list of errors for use with errors.Is/As.
 func (e *ValidationErrors) Unwrap() []error {
 	return e.Errors
@@ -99,20 +199,47 @@ func (e *ValidationErrors) Add(err error) {
 	e.Errors = append(e.Errors, err)
 }
 
-// AddMessage appends a new error with the given message.
+// AddMessage appends a new *ValidationError with the given message.
 func (e *ValidationErrors) AddMessage(msg string) {
-	e.Errors = append(e.Errors, errors.New(msg))
+	e.Errors = append(e.Errors, &ValidationError{Severity: SeverityError, Message: msg})
 }
 
-// HasErrors returns true if there are any validation errors.
+// HasErrors returns true if any finding has SeverityError.
+// Warnings and infos alone do not count as errors.
 func (e *ValidationErrors) HasErrors() bool {
-	return len(e.Errors) > 0
+	for _, err := range e.Errors {
+		if severityOfError(err) == SeverityError {
+			return true
+		}
+	}
+	return false
 }
 
-// AsError returns the ValidationErrors as an error, or nil if no errors.
+// BySeverity returns the findings with the given severity.
+func (e *ValidationErrors) BySeverity(sev Severity) []error {
+	var errs []error
+	for _, err := range e.Errors {
+		if severityOfError(err) == sev {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// AsError returns the ValidationErrors as an error, or nil if no finding
+// has SeverityError.
 func (e *ValidationErrors) AsError() error {
 	if !e.HasErrors() {
 		return nil
 	}
 	return e
 }
+
+// failure returns the findings with SeverityError as a *ValidationErrors,
+// or nil if there are none. Validate and ValidateFS return it.
+func (e *ValidationErrors) failure() error {
+	if !e.HasErrors() {
+		return nil
+	}
+	return &ValidationErrors{Errors: e.BySeverity(SeverityError)}
+}
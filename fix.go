@@ -0,0 +1,203 @@
+package agentskills
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// Fix is a suggested correction for a finding. Findings of the name,
+// skill.md and unexpected-field checks carry one when the correction is
+// mechanical; ApplyFixes applies them.
+type Fix struct {
+	// Description says what the fix does, such as
+	// "change name to 'pdf-reader'".
+	Description string
+
+	// Edit, if set, changes the frontmatter of the SKILL.md file.
+	Edit func(e *FrontmatterEditor) error
+
+	// RenameFile, if set, is the new name of the SKILL.md file.
+	RenameFile string
+
+	// RenameDir, if set, is the new name of the skill directory, which
+	// stays in the same parent directory. The name-directory-mismatch
+	// rule only suggests it when its "rename-directory" option is true.
+	RenameDir string
+}
+
+// FixResult reports the outcome of ApplyFixes.
+type FixResult struct {
+	// Dir is the skill directory after the fixes, which differs from the
+	// directory passed to ApplyFixes when it was renamed.
+	Dir string
+
+	// Applied lists the findings whose fixes were applied.
+	Applied []*ValidationError
+}
+
+// ApplyFixes applies the fixes carried by findings, as returned by Check,
+// to the skill in skillDir. Frontmatter edits are applied together, so the
+// SKILL.md file is either fully fixed or left unchanged; the file and then
+// the directory are renamed afterwards, never over an existing path. A
+// skillDir of "." or ".." is not renamed, as that would move the working
+// directory. Findings without a fix are ignored. Run Check on the returned
+// directory to see what remains.
+//
+// Example:
+//
+//	result, err := agentskills.ApplyFixes(dir, agentskills.Check(dir, agentskills.ValidateOptions{}).Errors)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	remaining := agentskills.Check(result.Dir, agentskills.ValidateOptions{})
+func ApplyFixes(skillDir string, findings []error) (*FixResult, error) {
+	result := &FixResult{Dir: skillDir}
+
+	var edits, renames []*ValidationError
+	for _, err := range findings {
+		var ve *ValidationError
+		if !errors.As(err, &ve) || ve.Fix == nil {
+			continue
+		}
+		if ve.Fix.Edit != nil {
+			edits = append(edits, ve)
+		} else {
+			renames = append(renames, ve)
+		}
+	}
+
+	if len(edits) > 0 {
+		err := Edit(skillDir, func(e *FrontmatterEditor) error {
+			for _, ve := range edits {
+				if err := ve.Fix.Edit(e); err != nil {
+					return fmt.Errorf("%s: %w", ve.Fix.Description, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Applied = append(result.Applied, edits...)
+	}
+
+	for _, ve := range renames {
+		if ve.Fix.RenameFile == "" {
+			continue
+		}
+		skillMD := findSkillMD(skillDir)
+		if skillMD == "" {
+			return result, &ParseError{Path: skillDir, Err: ErrSkillMDNotFound}
+		}
+		if err := renameNoReplace(skillMD, filepath.Join(skillDir, ve.Fix.RenameFile)); err != nil {
+			return result, err
+		}
+		result.Applied = append(result.Applied, ve)
+	}
+
+	for _, ve := range renames {
+		if ve.Fix.RenameDir == "" {
+			continue
+		}
+		dir := filepath.Clean(skillDir)
+		if base := filepath.Base(dir); base == "." || base == ".." {
+			return result, fmt.Errorf("cannot rename %s: it is the working directory or one of its parents", skillDir)
+		}
+		newDir := filepath.Join(filepath.Dir(dir), ve.Fix.RenameDir)
+		if err := renameNoReplace(dir, newDir); err != nil {
+			return result, err
+		}
+		result.Dir = newDir
+		result.Applied = append(result.Applied, ve)
+		break
+	}
+	return result, nil
+}
+
+// renameNoReplace renames from to to, failing with fs.ErrExist if to
+// already exists. A rename that only changes letter case is done through a
+// temporary name, so it also works on case-insensitive file systems.
+func renameNoReplace(from, to string) error {
+	if target, err := os.Lstat(to); err == nil {
+		source, err := os.Lstat(from)
+		if err != nil || !os.SameFile(source, target) {
+			return fmt.Errorf("cannot rename %s: %w: %s", from, fs.ErrExist, to)
+		}
+		tmp := from + ".rename"
+		if err := os.Rename(from, tmp); err != nil {
+			return err
+		}
+		from = tmp
+	}
+	return os.Rename(from, to)
+}
+
+// fixedName returns name lowercased, with runs of hyphens collapsed and
+// leading and trailing hyphens removed.
+func fixedName(name string) string {
+	name = strings.ToLower(name)
+	for strings.Contains(name, "--") {
+		name = strings.ReplaceAll(name, "--", "-")
+	}
+	return strings.Trim(name, "-")
+}
+
+// isValidName reports whether name passes the name checks with their
+// default limits.
+func isValidName(name string) bool {
+	if name == "" || len(name) > MaxSkillNameLength || name != fixedName(name) {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// nameFix returns a fix that changes the skill name to fixedName(name), or
+// nil if that leaves the name empty.
+func nameFix(name string) *Fix {
+	fixed := fixedName(name)
+	if fixed == "" || fixed == name {
+		return nil
+	}
+	return setFieldFix("name", fixed)
+}
+
+// setFieldFix returns a fix that sets the top-level field to value.
+func setFieldFix(field, value string) *Fix {
+	return &Fix{
+		Description: fmt.Sprintf("change %s to '%s'", field, value),
+		Edit: func(e *FrontmatterEditor) error {
+			return e.Set(field, value)
+		},
+	}
+}
+
+// moveToMetadataFix returns a fix that moves the top-level field into the
+// metadata field, keeping its value as written.
+func moveToMetadataFix(field string) *Fix {
+	return &Fix{
+		Description: fmt.Sprintf("move '%s' under metadata", field),
+		Edit: func(e *FrontmatterEditor) error {
+			value, ok := e.Get(field)
+			if !ok {
+				return fmt.Errorf("field '%s' is not a scalar", field)
+			}
+			if existing, ok := e.Metadata(field); ok && existing != value {
+				return fmt.Errorf("metadata already has a different '%s'", field)
+			}
+			if err := e.SetMetadata(field, value); err != nil {
+				return err
+			}
+			return e.Delete(field)
+		},
+	}
+}
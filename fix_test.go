@@ -0,0 +1,155 @@
+package agentskills
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeSkillMD creates dir with a file name holding content.
+func writeSkillMD(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fixesOf returns the fix descriptions of the findings, keyed by code.
+func fixesOf(errs []error) map[Code]string {
+	fixes := make(map[Code]string)
+	for _, err := range errs {
+		var ve *ValidationError
+		if errors.As(err, &ve) && ve.Fix != nil {
+			fixes[ve.Code] = ve.Fix.Description
+		}
+	}
+	return fixes
+}
+
+func TestApplyFixes_Name(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "pdf-reader")
+	writeSkillMD(t, dir, "SKILL.md", "---\n# The name.\nname: PDF--Reader-  # fix me\ndescription: Reads PDF files when the user asks about one.\nlicense: MIT\n---\nBody\n")
+
+	result := Check(dir, ValidateOptions{})
+	fixes := fixesOf(result.Errors)
+	for _, code := range []Code{CodeNameNotLowercase, CodeNameHyphenBoundary, CodeNameConsecutiveHyphens, CodeNameDirectoryMismatch} {
+		if fixes[code] != "change name to 'pdf-reader'" {
+			t.Errorf("%s: fix = %q", code, fixes[code])
+		}
+	}
+
+	fixed, err := ApplyFixes(dir, result.Errors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fixed.Dir != dir || len(fixed.Applied) != 4 {
+		t.Errorf("unexpected result: %+v", fixed)
+	}
+	if remaining := Check(dir, ValidateOptions{Strict: true}); len(remaining.Errors) != 0 {
+		t.Errorf("findings remain after fixing: %v", remaining)
+	}
+	content, _ := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if !strings.Contains(string(content), "# The name.\nname: pdf-reader  # fix me\n") {
+		t.Errorf("comments not preserved:\n%s", content)
+	}
+}
+
+func TestApplyFixes_NameFromDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "old-name")
+	writeSkillMD(t, dir, "SKILL.md", "---\nname: new-name\ndescription: Renamed skill used to test the mismatch fix.\nlicense: MIT\n---\nBody\n")
+
+	result := Check(dir, ValidateOptions{})
+	if got := fixesOf(result.Errors)[CodeNameDirectoryMismatch]; got != "change name to 'old-name'" {
+		t.Errorf("mismatch fix = %q", got)
+	}
+	fixed, err := ApplyFixes(dir, result.Errors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fixed.Dir != dir {
+		t.Errorf("Dir = %q, want %q", fixed.Dir, dir)
+	}
+	if err := Validate(dir); err != nil {
+		t.Errorf("fixed skill does not validate: %v", err)
+	}
+}
+
+func TestApplyFixes_RenameDir(t *testing.T) {
+	parent := t.TempDir()
+	writeConfig(t, parent, "rules:\n  name-directory-mismatch:\n    rename-directory: true\n")
+	dir := filepath.Join(parent, "old-name")
+	writeSkillMD(t, dir, "SKILL.md", "---\nname: New-Name\ndescription: Renamed skill used to test the directory fix.\nlicense: MIT\n---\nBody\n")
+
+	result := Check(dir, ValidateOptions{})
+	if got := fixesOf(result.Errors)[CodeNameDirectoryMismatch]; got != "rename directory to 'new-name'" {
+		t.Errorf("directory fix = %q", got)
+	}
+	fixed, err := ApplyFixes(dir, result.Errors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(parent, "new-name"); fixed.Dir != want {
+		t.Errorf("Dir = %q, want %q", fixed.Dir, want)
+	}
+	if err := Validate(fixed.Dir); err != nil {
+		t.Errorf("fixed skill does not validate: %v", err)
+	}
+
+	// A rename never replaces an existing directory.
+	dir = filepath.Join(parent, "other")
+	writeSkillMD(t, dir, "SKILL.md", "---\nname: new-name\ndescription: Clashes with the renamed skill directory.\n---\nBody\n")
+	if _, err := ApplyFixes(dir, Check(dir, ValidateOptions{}).Errors); !errors.Is(err, fs.ErrExist) {
+		t.Errorf("expected fs.ErrExist, got %v", err)
+	}
+
+	// Nor does it move the working directory.
+	t.Chdir(dir)
+	rename := &ValidationError{Code: CodeNameDirectoryMismatch, Fix: &Fix{RenameDir: "renamed"}}
+	if _, err := ApplyFixes(".", []error{rename}); err == nil {
+		t.Error("expected an error renaming the working directory")
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("working directory moved: %v", err)
+	}
+}
+
+func TestApplyFixes_FileAndFields(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my-skill")
+	writeSkillMD(t, dir, "skill.md", "---\nname: my-skill\ndescription: Checks that fields move under metadata.\nversion: 1.0\nmetadata:\n  owner: docs\ntags: [a, b]\nlicense: MIT\n---\nBody\n")
+
+	result := Check(dir, ValidateOptions{})
+	fixes := fixesOf(result.Errors)
+	if fixes[CodeSkillMDLowercase] == "" {
+		t.Error("expected a fix for skill.md")
+	}
+	fixed, err := ApplyFixes(dir, result.Errors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixed.Applied) != 2 {
+		t.Errorf("expected 2 fixes, applied %d", len(fixed.Applied))
+	}
+
+	skill, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(skill.Path) != "SKILL.md" {
+		t.Errorf("SKILL.md not renamed: %s", skill.Path)
+	}
+	if skill.Properties.Metadata["version"] != "1.0" || skill.Properties.Metadata["owner"] != "docs" {
+		t.Errorf("metadata = %v", skill.Properties.Metadata)
+	}
+
+	// The list-valued field cannot move and is still reported.
+	remaining := Check(dir, ValidateOptions{})
+	if len(remaining.Errors) != 1 || !errors.Is(remaining.Errors[0], ErrUnexpectedField) {
+		t.Errorf("expected only the tags finding, got: %v", remaining)
+	}
+}
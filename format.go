@@ -0,0 +1,367 @@
+package agentskills
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// FoldWidth is the line width at which Write and Format fold long
+// descriptions.
+const FoldWidth = 80
+
+// canonicalOrder lists the scalar fields in the order they are written,
+// before metadata and any fields not defined by the specification.
+var canonicalOrder = []string{"name", "description", "license", "compatibility", "allowed-tools"}
+
+// Write writes a SKILL.md with the given properties and markdown body in
+// canonical form: fields in specification order (name, description,
+// license, compatibility, allowed-tools, metadata with sorted keys), values
+// quoted only when YAML requires it, long descriptions folded at
+// FoldWidth, LF line endings and a single trailing newline.
+func Write(w io.Writer, props *SkillProperties, body string) error {
+	var fw frontmatterWriter
+	fields := map[string]string{
+		"name":          props.Name,
+		"description":   props.Description,
+		"license":       props.License,
+		"compatibility": props.Compatibility,
+		"allowed-tools": props.AllowedTools,
+	}
+	for _, key := range canonicalOrder {
+		if v := fields[key]; v != "" || key == "name" || key == "description" {
+			fw.scalar("", key, v)
+		}
+	}
+	if len(props.Metadata) > 0 {
+		fw.line("metadata:")
+		for _, k := range sortedKeys(props.Metadata) {
+			fw.scalar("  ", k, props.Metadata[k])
+		}
+	}
+
+	_, err := io.WriteString(w, assemble(fw.String(), body))
+	return err
+}
+
+// Format rewrites SKILL.md content in the canonical form produced by Write.
+// Fields not defined by the specification are kept, in their original
+// order, after the known ones. Metadata values are written as strings, as
+// the specification requires. The markdown body is kept unchanged apart
+// from line endings and surrounding blank lines. YAML comments in the
+// frontmatter are not preserved.
+func Format(content []byte) ([]byte, error) {
+	doc, err := parseDocument(content, "")
+	if err != nil {
+		return nil, err
+	}
+	frontmatter, err := canonicalFrontmatter(doc)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(assemble(frontmatter, doc.rawBody)), nil
+}
+
+// canonicalFrontmatter renders the frontmatter of doc in canonical form.
+func canonicalFrontmatter(doc *document) (string, error) {
+	file, err := parser.ParseBytes([]byte(doc.frontmatter), 0)
+	if err != nil {
+		return "", &ParseError{Err: err}
+	}
+
+	entries := make(map[string]*ast.MappingValueNode)
+	var order []string
+	if len(file.Docs) > 0 {
+		for _, mv := range mappingValues(file.Docs[0].Body) {
+			key := keyText(mv.Key)
+			if _, seen := entries[key]; !seen {
+				order = append(order, key)
+			}
+			entries[key] = mv
+		}
+	}
+
+	var fw frontmatterWriter
+	for _, key := range canonicalOrder {
+		mv, ok := entries[key]
+		if !ok {
+			continue
+		}
+		if s, ok := doc.metadata[key].(string); ok {
+			fw.scalar("", key, s)
+		} else if err := fw.node("", key, mv.Value); err != nil {
+			return "", err
+		}
+	}
+
+	if mv, ok := entries["metadata"]; ok {
+		if err := fw.metadata(mv.Value); err != nil {
+			return "", err
+		}
+	}
+
+	for _, key := range order {
+		if allowedFields[key] {
+			continue
+		}
+		if err := fw.node("", key, entries[key].Value); err != nil {
+			return "", err
+		}
+	}
+	return fw.String(), nil
+}
+
+// mappingValues returns the entries of a YAML mapping node, looking
+// through anchors and tags.
+func mappingValues(node ast.Node) []*ast.MappingValueNode {
+	switch n := node.(type) {
+	case *ast.AnchorNode:
+		return mappingValues(n.Value)
+	case *ast.TagNode:
+		return mappingValues(n.Value)
+	case *ast.MappingNode:
+		return n.Values
+	case *ast.MappingValueNode:
+		return []*ast.MappingValueNode{n}
+	}
+	return nil
+}
+
+// assemble joins frontmatter and body into SKILL.md content. Blank lines
+// around the body are dropped, and it is separated from the frontmatter by
+// one blank line.
+func assemble(frontmatter, body string) string {
+	lines := strings.Split(newlineReplacer.Replace(body), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	sb.WriteString(frontmatter)
+	sb.WriteString("---\n")
+	if len(lines) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(strings.Join(lines, "\n"))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// frontmatterWriter accumulates canonical YAML frontmatter lines.
+type frontmatterWriter struct {
+	sb strings.Builder
+}
+
+func (w *frontmatterWriter) String() string {
+	return w.sb.String()
+}
+
+func (w *frontmatterWriter) line(s string) {
+	w.sb.WriteString(s)
+	w.sb.WriteString("\n")
+}
+
+// scalar writes "key: value" at indent, choosing the scalar style: plain
+// when YAML allows it, a folded block for long descriptions, a literal
+// block for multi-line text, and double quotes otherwise.
+func (w *frontmatterWriter) scalar(indent, key, value string) {
+	prefix := indent + yamlString(key) + ":"
+	contentIndent := indent + "  "
+
+	switch {
+	case strings.Contains(value, "\n") && literalSafe(value):
+		w.line(prefix + " |-")
+		for _, l := range strings.Split(value, "\n") {
+			if l == "" {
+				w.line("")
+			} else {
+				w.line(contentIndent + l)
+			}
+		}
+	case key == "description" && indent == "" &&
+		utf8.RuneCountInString(prefix)+1+utf8.RuneCountInString(yamlString(value)) > FoldWidth && foldSafe(value):
+		w.line(prefix + " >-")
+		for _, l := range wrapWords(value, FoldWidth-len(contentIndent)) {
+			w.line(contentIndent + l)
+		}
+	default:
+		w.line(prefix + " " + yamlString(value))
+	}
+}
+
+// metadata writes the metadata field with its entries sorted by key and
+// their scalar values as strings. Other shapes are written unchanged.
+func (w *frontmatterWriter) metadata(node ast.Node) error {
+	values := mappingValues(node)
+	if len(values) == 0 || node.Type() != ast.MappingType {
+		return w.node("", "metadata", node)
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		return keyText(values[i].Key) < keyText(values[j].Key)
+	})
+	w.line("metadata:")
+	for _, mv := range values {
+		key := keyText(mv.Key)
+		if text, ok := scalarText(mv.Value); ok {
+			w.scalar("  ", key, text)
+		} else if err := w.node("  ", key, mv.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// node writes an arbitrary YAML value, preserving mapping order. Values
+// holding multi-line strings that a literal block cannot represent are
+// written in JSON-compatible flow style, since the YAML encoder would lose
+// their whitespace.
+func (w *frontmatterWriter) node(indent, key string, node ast.Node) error {
+	var value any
+	if err := yaml.NodeToValue(node, &value, yaml.UseOrderedMap()); err != nil {
+		return &ParseError{Err: err}
+	}
+	if s, ok := value.(string); ok {
+		w.scalar(indent, key, s)
+		return nil
+	}
+	if !blockSafe(value) {
+		out, err := yaml.MarshalWithOptions(value, yaml.JSON())
+		if err != nil {
+			return err
+		}
+		w.line(indent + yamlString(key) + ": " + strings.TrimRight(string(out), "\n"))
+		return nil
+	}
+	out, err := yaml.Marshal(yaml.MapSlice{{Key: "k", Value: value}})
+	if err != nil {
+		return err
+	}
+	out = append([]byte(yamlString(key)), bytes.TrimPrefix(out, []byte("k"))...)
+	for _, l := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		w.line(indent + l)
+	}
+	return nil
+}
+
+// keyText returns a mapping key as the string it decodes to, so that keys
+// such as "001" are written as they are read.
+func keyText(node ast.Node) string {
+	var key string
+	if err := yaml.NodeToValue(node, &key); err != nil {
+		return node.GetToken().Value
+	}
+	return key
+}
+
+// scalarText returns the text of a scalar node as written in the source,
+// so that metadata such as "version: 1.0" keeps its spelling.
+func scalarText(node ast.Node) (string, bool) {
+	switch n := node.(type) {
+	case *ast.StringNode:
+		return n.Value, true
+	case *ast.LiteralNode:
+		return n.Value.Value, true
+	case *ast.IntegerNode, *ast.FloatNode, *ast.BoolNode:
+		return n.GetToken().Value, true
+	}
+	return "", false
+}
+
+// yamlString returns s as a YAML scalar: plain if that reads back as the
+// same string, and double-quoted otherwise.
+func yamlString(s string) string {
+	if out, err := yaml.Marshal(s); err == nil && string(out) == s+"\n" {
+		return s
+	}
+	return strconv.Quote(s)
+}
+
+// literalSafe reports whether s can be written as a "|-" literal block and
+// read back unchanged.
+func literalSafe(s string) bool {
+	if strings.HasPrefix(s, " ") || strings.HasSuffix(s, "\n") || strings.HasPrefix(s, "\n") {
+		return false
+	}
+	for _, r := range s {
+		if r != '\n' && r != '\t' && (r < ' ' || r == 0x7f || r == utf8.RuneError || r == '\ufeff') {
+			return false
+		}
+	}
+	for _, l := range strings.Split(s, "\n") {
+		if strings.TrimRight(l, " \t") != l {
+			return false
+		}
+	}
+	return true
+}
+
+// blockSafe reports whether every multi-line string in value is
+// literalSafe.
+func blockSafe(value any) bool {
+	switch v := value.(type) {
+	case string:
+		return !strings.ContainsAny(v, "\n\r") || literalSafe(v)
+	case []any:
+		for _, item := range v {
+			if !blockSafe(item) {
+				return false
+			}
+		}
+	case yaml.MapSlice:
+		for _, item := range v {
+			if !blockSafe(item.Key) || !blockSafe(item.Value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// foldSafe reports whether s can be written as a ">-" folded block and read
+// back unchanged: its words must be separated by single spaces.
+func foldSafe(s string) bool {
+	return strings.Join(strings.Fields(s), " ") == s && literalSafe(s)
+}
+
+// wrapWords splits s at spaces into lines of at most width runes, except
+// where a single word is longer.
+func wrapWords(s string, width int) []string {
+	var lines []string
+	var cur string
+	for _, word := range strings.Fields(s) {
+		if cur != "" && utf8.RuneCountInString(cur)+1+utf8.RuneCountInString(word) > width {
+			lines = append(lines, cur)
+			cur = ""
+		}
+		if cur != "" {
+			cur += " "
+		}
+		cur += word
+	}
+	if cur != "" {
+		lines = append(lines, cur)
+	}
+	return lines
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
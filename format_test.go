@@ -0,0 +1,202 @@
+package agentskills
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	props := &SkillProperties{
+		Name:          "pdf-reader",
+		Description:   "Reads PDF files: use when the user attaches a PDF and asks about its contents or wants text extracted.",
+		License:       "MIT",
+		Compatibility: "Requires poppler-utils",
+		AllowedTools:  "Bash(pdftotext:*)",
+		Metadata:      map[string]string{"version": "1.0", "author": "Docs Team"},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, props, "\n\n# PDF Reader\r\n\r\nSteps.\n\n\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `---
+name: pdf-reader
+description: >-
+  Reads PDF files: use when the user attaches a PDF and asks about its contents
+  or wants text extracted.
+license: MIT
+compatibility: Requires poppler-utils
+allowed-tools: Bash(pdftotext:*)
+metadata:
+  author: Docs Team
+  version: "1.0"
+---
+
+# PDF Reader
+
+Steps.
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Write:\n%s\nwant:\n%s", got, want)
+	}
+
+	result, err := ParseBytes(buf.Bytes(), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := result.Properties
+	if got.Description != props.Description || got.Metadata["version"] != "1.0" || got.AllowedTools != props.AllowedTools {
+		t.Errorf("round trip changed properties: %+v", got)
+	}
+}
+
+func TestWrite_Quoting(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"plain text", "license: plain text\n"},
+		{"yes", "license: \"yes\"\n"},
+		{"123", "license: \"123\"\n"},
+		{"a: b", "license: \"a: b\"\n"},
+		{"#hash", "license: \"#hash\"\n"},
+		{" leading", "license: \" leading\"\n"},
+		{"line one\nline two", "license: |-\n  line one\n  line two\n"},
+		{"trailing newline\n", "license: \"trailing newline\\n\"\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := Write(&buf, &SkillProperties{Name: "x", Description: "d", License: tt.value}, ""); err != nil {
+			t.Fatal(err)
+		}
+		_, rest, _ := strings.Cut(buf.String(), "description: d\n")
+		got, _, _ := strings.Cut(rest, "---\n")
+		if got != tt.want {
+			t.Errorf("license %q: got %q, want %q", tt.value, got, tt.want)
+		}
+
+		result, err := ParseBytes(buf.Bytes(), ParseOptions{})
+		if err != nil {
+			t.Fatalf("license %q: %v", tt.value, err)
+		}
+		if result.Properties.License != tt.value {
+			t.Errorf("license %q read back as %q", tt.value, result.Properties.License)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	in := "\ufeff---\r\n" +
+		"# comment\r\n" +
+		"extra: [1, 2]\r\n" +
+		"metadata:\r\n" +
+		"  version: 1.0\r\n" +
+		"  author: 'Me'\r\n" +
+		"license: \"MIT\"\r\n" +
+		"description: Does things when asked to.\r\n" +
+		"name: my-skill\r\n" +
+		"---\r\n\r\n\r\n# Body\r\n\r\n    code  \r\n\r\n"
+
+	want := `---
+name: my-skill
+description: Does things when asked to.
+license: MIT
+metadata:
+  author: Me
+  version: "1.0"
+extra:
+- 1
+- 2
+---
+
+# Body
+
+    code  
+`
+	out, err := Format([]byte(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != want {
+		t.Errorf("Format:\n%s\nwant:\n%s", out, want)
+	}
+
+	again, err := Format(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(again, out) {
+		t.Errorf("Format is not idempotent:\n%s", again)
+	}
+}
+
+func TestFormat_Errors(t *testing.T) {
+	for _, in := range []string{"no frontmatter", "---\nname: [\n---\n"} {
+		if _, err := Format([]byte(in)); err == nil {
+			t.Errorf("Format(%q): expected error", in)
+		}
+	}
+}
+
+func TestFormat_TestdataCanonical(t *testing.T) {
+	dirs, err := filepath.Glob("testdata/*/SKILL.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range dirs {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := Format(content)
+		if err != nil {
+			t.Errorf("%s: %v", file, err)
+			continue
+		}
+		before, _ := ParseBytes(content, ParseOptions{})
+		after, err := ParseBytes(out, ParseOptions{})
+		if err != nil {
+			t.Errorf("%s: formatted output does not parse: %v", file, err)
+			continue
+		}
+		if before.Body != after.Body || before.Properties.Name != after.Properties.Name ||
+			before.Properties.Description != after.Properties.Description {
+			t.Errorf("%s: Format changed the skill:\n%s", file, out)
+		}
+	}
+}
+
+func FuzzFormat(f *testing.F) {
+	f.Add("---\nname: test\ndescription: A test\n---\nBody\n")
+	f.Add("---\ndescription: >\n  folded\n  text\nname: x\nmetadata:\n  a: 1\n  b: |\n    two\n    lines\nother: {k: v}\n---\n")
+	f.Add("---\r\nname: \"quoted: value\"\r\ndescription: '#x'\r\n---\r\n")
+	f.Add("---\n&000000000\n000: 0000000\n---")
+	f.Add("---\n0: >\n 0000000\n  \n---")
+	f.Add("---\n00000000000: 0000000000000000\n001:   |\n\n:\n---")
+
+	f.Fuzz(func(t *testing.T, content string) {
+		out, err := Format([]byte(content))
+		if err != nil {
+			return
+		}
+		again, err := Format(out)
+		if err != nil {
+			t.Fatalf("formatted output does not parse: %v\n%q", err, out)
+		}
+		if !bytes.Equal(again, out) {
+			t.Fatalf("Format is not idempotent:\n%q\n%q", out, again)
+		}
+
+		before, _ := ParseBytes([]byte(content), ParseOptions{})
+		after, _ := ParseBytes(out, ParseOptions{})
+		if before.Properties.Name != after.Properties.Name ||
+			before.Properties.Description != after.Properties.Description ||
+			before.Properties.License != after.Properties.License {
+			t.Fatalf("Format changed properties:\n%q\n%q", content, out)
+		}
+	})
+}
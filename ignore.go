@@ -0,0 +1,112 @@
+package agentskills
+
+import (
+	"path"
+	"strings"
+)
+
+// ignorePattern is a single compiled .gitignore-style pattern.
+type ignorePattern struct {
+	segments []string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// ignoreMatcher matches slash-separated relative paths against a list of
+// .gitignore-style patterns. As in git, the last matching pattern wins, so a
+// later "!pattern" re-includes paths excluded by an earlier one.
+type ignoreMatcher []ignorePattern
+
+// newIgnoreMatcher compiles patterns using .gitignore syntax:
+//   - blank lines and lines starting with "#" are skipped
+//   - a leading "!" negates the pattern
+//   - a trailing "/" only matches directories
+//   - a pattern containing "/" is anchored to the root, otherwise it
+//     matches a file or directory name at any depth
+//   - "*", "?" and "[...]" match within a path segment, "**" matches
+//     any number of segments
+func newIgnoreMatcher(patterns []string) ignoreMatcher {
+	var m ignoreMatcher
+	for _, raw := range patterns {
+		p := strings.TrimRight(raw, " \t\r")
+		if p == "" || strings.HasPrefix(p, "#") {
+			continue
+		}
+
+		var ip ignorePattern
+		switch {
+		case strings.HasPrefix(p, "!"):
+			ip.negate = true
+			p = p[1:]
+		case strings.HasPrefix(p, `\!`), strings.HasPrefix(p, `\#`):
+			p = p[1:]
+		}
+
+		if strings.HasSuffix(p, "/") {
+			ip.dirOnly = true
+			p = strings.TrimRight(p, "/")
+		}
+		if strings.Contains(p, "/") {
+			ip.anchored = true
+			p = strings.TrimPrefix(p, "/")
+		}
+		if p == "" {
+			continue
+		}
+
+		ip.segments = strings.Split(p, "/")
+		m = append(m, ip)
+	}
+	return m
+}
+
+// match reports whether rel (slash-separated, relative to the root) is ignored.
+func (m ignoreMatcher) match(rel string, isDir bool) bool {
+	ignored := false
+	for _, p := range m {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if p.matches(rel) {
+			ignored = !p.negate
+		}
+	}
+	return ignored
+}
+
+func (p ignorePattern) matches(rel string) bool {
+	if !p.anchored {
+		return matchSegments(p.segments, []string{path.Base(rel)})
+	}
+	return matchSegments(p.segments, strings.Split(rel, "/"))
+}
+
+// matchSegments matches path segments against pattern segments, where a
+// "**" pattern segment matches zero or more path segments.
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for len(pattern) > 0 && pattern[0] == "**" {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := range name {
+				if matchSegments(pattern, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,37 @@
+package agentskills
+
+import "testing"
+
+func TestIgnoreMatcher(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		path     string
+		isDir    bool
+		want     bool
+	}{
+		{[]string{"build"}, "build", true, true},
+		{[]string{"build"}, "a/b/build", true, true},
+		{[]string{"build/"}, "build", false, false},
+		{[]string{"/build"}, "a/build", true, false},
+		{[]string{"/build"}, "build", true, true},
+		{[]string{"a/*/c"}, "a/b/c", true, true},
+		{[]string{"a/*/c"}, "a/b/x/c", true, false},
+		{[]string{"a/**/c"}, "a/b/x/c", true, true},
+		{[]string{"a/**/c"}, "a/c", true, true},
+		{[]string{"**/tmp"}, "x/y/tmp", true, true},
+		{[]string{"*.bak"}, "dir/file.bak", false, true},
+		{[]string{"skill-?"}, "skill-a", true, true},
+		{[]string{"*", "!keep"}, "keep", true, false},
+		{[]string{"!keep", "*"}, "keep", true, true},
+		{[]string{`\!important`}, "!important", true, true},
+		{[]string{"", "# comment"}, "comment", true, false},
+	}
+
+	for _, tt := range tests {
+		m := newIgnoreMatcher(tt.patterns)
+		if got := m.match(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("patterns %q match(%q, dir=%v) = %v, want %v",
+				tt.patterns, tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
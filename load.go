@@ -0,0 +1,85 @@
+package agentskills
+
+import (
+	"io/fs"
+	"strings"
+)
+
+// Load reads the complete skill in skillDir: properties, markdown body, raw
+// frontmatter and the list of bundled resource files.
+// Like ReadProperties, it checks that the required fields exist but does NOT
+// perform full validation.
+func Load(skillDir string) (*Skill, error) {
+	return loadSkill(osSkillFS(skillDir))
+}
+
+// LoadFS is like Load but reads the skill directory dir from fsys.
+// Paths in the returned Skill are slash-separated paths within fsys.
+func LoadFS(fsys fs.FS, dir string) (*Skill, error) {
+	return loadSkill(newSkillFS(fsys, dir))
+}
+
+func loadSkill(s skillFS) (*Skill, error) {
+	name, content, err := s.readSkillMD()
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := parseDocument(content, s.path(name))
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := propertiesFromMetadata(doc.metadata, doc.src)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := s.listResources(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Skill{
+		Properties:  props,
+		Body:        doc.body,
+		Dir:         s.base,
+		Path:        s.path(name),
+		Frontmatter: doc.frontmatter,
+		Resources:   resources,
+	}, nil
+}
+
+// listResources returns the files in the skill directory other than the
+// SKILL.md file named skillMD, as slash-separated relative paths.
+// Version control directories are skipped.
+func (s skillFS) listResources(skillMD string) ([]string, error) {
+	var resources []string
+	err := fs.WalkDir(s.fsys, s.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		rel := p
+		if s.dir != "." {
+			rel = p[len(s.dir)+1:]
+		}
+		// EqualFold also skips the file on case-insensitive file systems,
+		// where findSkillMD may report SKILL.md for a skill.md file.
+		if strings.EqualFold(rel, skillMD) {
+			return nil
+		}
+		resources = append(resources, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, &ParseError{Path: s.base, Err: err}
+	}
+	return resources, nil
+}
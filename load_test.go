@@ -0,0 +1,86 @@
+package agentskills
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad_ValidSkill(t *testing.T) {
+	skill, err := Load("testdata/valid-skill")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if skill.Properties.Name != "valid-skill" {
+		t.Errorf("expected name 'valid-skill', got %q", skill.Properties.Name)
+	}
+	if skill.Body != "# Valid Skill\n\nThis is a valid skill for testing." {
+		t.Errorf("unexpected body: %q", skill.Body)
+	}
+	if skill.Frontmatter != "name: valid-skill\ndescription: A valid test skill\n" {
+		t.Errorf("unexpected frontmatter: %q", skill.Frontmatter)
+	}
+	if skill.Dir != "testdata/valid-skill" {
+		t.Errorf("unexpected dir: %q", skill.Dir)
+	}
+	if skill.Path != filepath.Join("testdata", "valid-skill", "SKILL.md") {
+		t.Errorf("unexpected path: %q", skill.Path)
+	}
+	if len(skill.Resources) != 0 {
+		t.Errorf("expected no resources, got %v", skill.Resources)
+	}
+}
+
+func TestLoad_ListsResources(t *testing.T) {
+	skill, err := Load("testdata/with-resources")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"references/guide.md", "scripts/run.sh"}
+	if !equalStrings(skill.Resources, expected) {
+		t.Errorf("expected resources %v, got %v", expected, skill.Resources)
+	}
+}
+
+func TestLoad_MissingName(t *testing.T) {
+	_, err := Load("testdata/missing-name")
+	if !errors.Is(err, ErrMissingName) {
+		t.Errorf("expected ErrMissingName, got %v", err)
+	}
+}
+
+func TestLoad_MissingSkillMD(t *testing.T) {
+	_, err := Load("testdata/nonexistent")
+	if !errors.Is(err, ErrSkillMDNotFound) {
+		t.Errorf("expected ErrSkillMDNotFound, got %v", err)
+	}
+}
+
+func TestLoadFS_MapFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"skills/my-skill/SKILL.md":          {Data: []byte("---\nname: my-skill\ndescription: In memory\n---\n\nDo the thing.\n")},
+		"skills/my-skill/assets/logo.svg":   {Data: []byte("<svg/>")},
+		"skills/my-skill/.git/HEAD":         {Data: []byte("ref: refs/heads/main")},
+		"skills/other-skill/SKILL.md":       {Data: []byte("---\nname: other-skill\ndescription: Not included\n---\n")},
+		"skills/my-skill/scripts/helper.py": {Data: []byte("print('hi')")},
+	}
+
+	skill, err := LoadFS(fsys, "skills/my-skill")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if skill.Body != "Do the thing." {
+		t.Errorf("unexpected body: %q", skill.Body)
+	}
+	if skill.Path != "skills/my-skill/SKILL.md" {
+		t.Errorf("unexpected path: %q", skill.Path)
+	}
+	expected := []string{"assets/logo.svg", "scripts/helper.py"}
+	if !equalStrings(skill.Resources, expected) {
+		t.Errorf("expected resources %v, got %v", expected, skill.Resources)
+	}
+}
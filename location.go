@@ -0,0 +1,83 @@
+package agentskills
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocationFunc rewrites the location of a skill's SKILL.md, as reported by
+// PromptSkills or PromptSkillsFS, into the location shown in the prompt.
+// Renderers leave the location out when it is empty.
+type LocationFunc func(location string) (string, error)
+
+// OmitLocation leaves locations out of the prompt, for agents that look
+// skills up by name.
+func OmitLocation(string) (string, error) {
+	return "", nil
+}
+
+// FileURILocation shows locations as file:// URIs.
+func FileURILocation(location string) (string, error) {
+	abs, err := filepath.Abs(location)
+	if err != nil {
+		return "", err
+	}
+	p := filepath.ToSlash(abs)
+	if !strings.HasPrefix(p, "/") {
+		// A Windows drive letter: file:///C:/skills/...
+		p = "/" + p
+	}
+	return (&url.URL{Scheme: "file", Path: p}).String(), nil
+}
+
+// RelativeLocation shows locations relative to base, with forward slashes,
+// so that the prompt does not reveal where the skills are installed.
+// Relative locations are resolved against the working directory.
+func RelativeLocation(base string) LocationFunc {
+	return func(location string) (string, error) {
+		absBase, err := filepath.Abs(base)
+		if err != nil {
+			return "", err
+		}
+		absLocation, err := filepath.Abs(location)
+		if err != nil {
+			return "", err
+		}
+		rel, err := filepath.Rel(absBase, absLocation)
+		if err != nil {
+			return "", err
+		}
+		return filepath.ToSlash(rel), nil
+	}
+}
+
+// MapLocation shows locations under the host directory hostDir as the
+// same paths under agentDir, for agents that see the skills at another
+// path, such as a container mount. agentDir is a slash-separated path.
+// Locations outside hostDir are an error rather than being leaked.
+//
+// Example:
+//
+//	opts := agentskills.PromptOptions{
+//	    Location: agentskills.MapLocation("/home/me/skills", "/mnt/skills"),
+//	}
+func MapLocation(hostDir, agentDir string) LocationFunc {
+	return func(location string) (string, error) {
+		absHost, err := filepath.Abs(hostDir)
+		if err != nil {
+			return "", err
+		}
+		absLocation, err := filepath.Abs(location)
+		if err != nil {
+			return "", err
+		}
+		rel, err := filepath.Rel(absHost, absLocation)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("location %s is outside %s", location, hostDir)
+		}
+		return path.Join(agentDir, filepath.ToSlash(rel)), nil
+	}
+}
@@ -0,0 +1,81 @@
+package agentskills
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestLocationFuncs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses Unix paths")
+	}
+	location := "/home/me/skills/pdf/SKILL.md"
+	tests := []struct {
+		name    string
+		fn      LocationFunc
+		want    string
+		wantErr bool
+	}{
+		{"omit", OmitLocation, "", false},
+		{"uri", FileURILocation, "file:///home/me/skills/pdf/SKILL.md", false},
+		{"relative", RelativeLocation("/home/me/skills"), "pdf/SKILL.md", false},
+		{"relative parent", RelativeLocation("/home/me/skills/other"), "../pdf/SKILL.md", false},
+		{"map", MapLocation("/home/me/skills", "/mnt/skills"), "/mnt/skills/pdf/SKILL.md", false},
+		{"map trailing slash", MapLocation("/home/me/skills/", "/mnt/skills/"), "/mnt/skills/pdf/SKILL.md", false},
+		{"map outside", MapLocation("/home/me/other", "/mnt/skills"), "", true},
+		{"map sibling prefix", MapLocation("/home/me/ski", "/mnt/skills"), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(location)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got, _ := FileURILocation("/skills/a b/SKILL.md"); got != "file:///skills/a%20b/SKILL.md" {
+		t.Errorf("FileURILocation did not escape the path: %q", got)
+	}
+}
+
+func TestRenderPrompt_Location(t *testing.T) {
+	skills, err := PromptSkills([]string{"testdata/valid-skill"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !filepath.IsAbs(skills[0].Location) {
+		t.Fatalf("expected an absolute location, got %q", skills[0].Location)
+	}
+
+	result, err := RenderPrompt(skills, PromptOptions{Location: RelativeLocation("testdata")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(result.Prompt, "<location>\nvalid-skill/SKILL.md\n</location>") {
+		t.Errorf("expected a relative location, got:\n%s", result.Prompt)
+	}
+	if result.Skills[0].Location != "valid-skill/SKILL.md" {
+		t.Errorf("result skill location = %q", result.Skills[0].Location)
+	}
+
+	renderers := map[string]PromptRenderer{"xml": XMLRenderer{}, "markdown": MarkdownRenderer{}, "json": JSONRenderer{}, "text": TextRenderer{}}
+	for name, r := range renderers {
+		result, err := RenderPrompt(skills, PromptOptions{Renderer: r, Location: OmitLocation})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(strings.ToLower(result.Prompt), "location") || strings.Contains(result.Prompt, "SKILL.md") {
+			t.Errorf("%s: expected no location, got:\n%s", name, result.Prompt)
+		}
+	}
+
+	if _, err := RenderPrompt(skills, PromptOptions{Location: MapLocation("/nonexistent", "/mnt")}); err == nil {
+		t.Error("expected an error for a location outside the mapped directory")
+	}
+}
@@ -2,48 +2,165 @@ package agentskills
 
 import (
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/goccy/go-yaml"
 )
 
+// skillFS addresses a skill directory inside a file system. Paths reported
+// in errors and prompts are built from base using join, so callers of the
+// OS-based functions keep seeing native paths while fs.FS callers see
+// slash-separated ones.
+type skillFS struct {
+	fsys fs.FS
+	dir  string // slash-separated path of the skill directory within fsys
+	base string // display path of the skill directory
+	join func(elem ...string) string
+	os   bool // fsys is the local disk rooted at base
+}
+
+// osSkillFS returns a skillFS for a directory on the local disk.
+func osSkillFS(skillDir string) skillFS {
+	root := skillDir
+	if root == "" {
+		root = "."
+	}
+	return skillFS{fsys: os.DirFS(root), dir: ".", base: skillDir, join: filepath.Join, os: true}
+}
+
+// newSkillFS returns a skillFS for dir within fsys.
+func newSkillFS(fsys fs.FS, dir string) skillFS {
+	return skillFS{fsys: fsys, dir: path.Clean(dir), base: dir, join: path.Join}
+}
+
+// path returns the display path of name inside the skill directory.
+func (s skillFS) path(name string) string {
+	return s.join(s.base, name)
+}
+
+// stat returns information about the skill directory itself.
+func (s skillFS) stat() (fs.FileInfo, error) {
+	if s.os {
+		// os.DirFS cannot stat its own root when that root is a file.
+		return os.Stat(s.join(s.base, "."))
+	}
+	return fs.Stat(s.fsys, s.dir)
+}
+
+// nameDir returns the path whose base name must match the skill name, or
+// empty string when the directory has no name of its own (the root of an
+// fs.FS).
+func (s skillFS) nameDir() string {
+	if s.os {
+		if abs, err := filepath.Abs(s.base); err == nil {
+			return abs
+		}
+		return s.base
+	}
+	if s.dir == "." {
+		return ""
+	}
+	return s.dir
+}
+
+// findSkillMD returns the file name of the skill's SKILL.md, or empty string
+// if not found. It prefers SKILL.md (uppercase) but accepts skill.md (lowercase).
+func (s skillFS) findSkillMD() string {
+	for _, name := range []string{"SKILL.md", "skill.md"} {
+		if _, err := fs.Stat(s.fsys, path.Join(s.dir, name)); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// readSkillMD locates and reads the skill's SKILL.md file.
+// Returns the file name and its content.
+func (s skillFS) readSkillMD() (name string, content []byte, err error) {
+	name = s.findSkillMD()
+	if name == "" {
+		return "", nil, &ParseError{Path: s.base, Err: ErrSkillMDNotFound}
+	}
+
+	content, err = fs.ReadFile(s.fsys, path.Join(s.dir, name))
+	if err != nil {
+		return "", nil, &ParseError{Path: s.path(name), Err: err}
+	}
+	return name, content, nil
+}
+
 // findSkillMD finds the SKILL.md file in a skill directory.
 // It prefers SKILL.md (uppercase) but accepts skill.md (lowercase).
 // Returns the full path to the file, or empty string if not found.
 func findSkillMD(skillDir string) string {
-	for _, name := range []string{"SKILL.md", "skill.md"} {
-		path := filepath.Join(skillDir, name)
-		if _, err := os.Stat(path); err == nil {
-			return path
-		}
+	s := osSkillFS(skillDir)
+	name := s.findSkillMD()
+	if name == "" {
+		return ""
 	}
-	return ""
+	return s.path(name)
 }
 
-// parseFrontmatter parses YAML frontmatter from SKILL.md content.
-// Returns the parsed metadata map and the markdown body.
-func parseFrontmatter(content string) (metadata map[string]any, body string, err error) {
-	if !strings.HasPrefix(content, "---") {
-		return nil, "", &ParseError{Err: ErrMissingFrontmatter}
+// splitFrontmatter splits SKILL.md content into the raw YAML frontmatter
+// and the markdown body.
+//
+// The content must start with a line consisting solely of "---". The
+// frontmatter ends at the next line consisting solely of "---" or "...", so
+// "---" inside a YAML value is not mistaken for a delimiter. A leading UTF-8
+// byte order mark is ignored and CRLF (and lone CR) line endings are
+// normalized to LF.
+func splitFrontmatter(content string) (frontmatter, body string, err error) {
+	frontmatter, body, err = splitFrontmatterRaw(content)
+	return frontmatter, strings.TrimSpace(body), err
+}
+
+// splitFrontmatterRaw is like splitFrontmatter but returns the body exactly
+// as it follows the closing delimiter line, with normalized line endings.
+func splitFrontmatterRaw(content string) (frontmatter, body string, err error) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	content = newlineReplacer.Replace(content)
+
+	first, rest, _ := strings.Cut(content, "\n")
+	if !isDelimiterLine(first, "---") {
+		return "", "", &ParseError{Err: ErrMissingFrontmatter}
 	}
 
-	// Split on "---" delimiter
-	parts := strings.SplitN(content, "---", 3)
-	if len(parts) < 3 {
-		return nil, "", &ParseError{Err: ErrUnclosedFrontmatter}
+	offset := 0
+	for offset < len(rest) {
+		line, _, _ := strings.Cut(rest[offset:], "\n")
+		if isDelimiterLine(line, "---") || isDelimiterLine(line, "...") {
+			frontmatter = rest[:offset]
+			body = rest[min(offset+len(line)+1, len(rest)):]
+			return frontmatter, body, nil
+		}
+		offset += len(line) + 1
 	}
 
-	frontmatterStr := parts[1]
-	body = strings.TrimSpace(parts[2])
+	return "", "", &ParseError{Err: ErrUnclosedFrontmatter}
+}
+
+// newlineReplacer normalizes CRLF and CR line breaks to LF.
+var newlineReplacer = strings.NewReplacer("\r\n", "\n", "\r", "\n")
 
-	if err = yaml.Unmarshal([]byte(frontmatterStr), &metadata); err != nil {
-		return nil, "", &ParseError{Err: fmt.Errorf("%w: %v", ErrInvalidYAML, err)}
+// isDelimiterLine reports whether line consists solely of marker, ignoring
+// trailing spaces and tabs.
+func isDelimiterLine(line, marker string) bool {
+	return strings.TrimRight(line, " \t") == marker
+}
+
+// parseMetadata parses raw YAML frontmatter into a metadata map.
+func parseMetadata(frontmatter string) (metadata map[string]any, err error) {
+	if err = yaml.Unmarshal([]byte(frontmatter), &metadata); err != nil {
+		return nil, &ParseError{Err: fmt.Errorf("%w: %w", ErrInvalidYAML, err)}
 	}
 
 	if metadata == nil {
-		return nil, "", &ParseError{Err: ErrFrontmatterNotMapping}
+		return nil, &ParseError{Err: ErrFrontmatterNotMapping}
 	}
 
 	// Convert metadata values to strings where appropriate
@@ -57,7 +174,53 @@ func parseFrontmatter(content string) (metadata map[string]any, body string, err
 		}
 	}
 
-	return metadata, body, nil
+	return metadata, nil
+}
+
+// document is parsed SKILL.md content.
+type document struct {
+	metadata    map[string]any
+	frontmatter string
+	body        string // trimmed of surrounding whitespace
+	rawBody     string
+	src         *sourceMap
+}
+
+// parseDocument parses SKILL.md content read from file, which may be empty
+// when the content does not come from a file. Errors carry their position
+// within the content.
+func parseDocument(content []byte, file string) (*document, error) {
+	src := newSourceMap(file, content)
+
+	frontmatter, rawBody, err := splitFrontmatterRaw(string(content))
+	if err != nil {
+		return nil, src.annotate(err)
+	}
+
+	metadata, err := parseMetadata(frontmatter)
+	if err != nil {
+		return nil, src.annotate(err)
+	}
+
+	src.indexFrontmatter(frontmatter)
+
+	return &document{
+		metadata:    metadata,
+		frontmatter: frontmatter,
+		body:        strings.TrimSpace(rawBody),
+		rawBody:     rawBody,
+		src:         src,
+	}, nil
+}
+
+// parseFrontmatter parses YAML frontmatter from SKILL.md content.
+// Returns the parsed metadata map and the markdown body.
+func parseFrontmatter(content string) (metadata map[string]any, body string, err error) {
+	doc, err := parseDocument([]byte(content), "")
+	if err != nil {
+		return nil, "", err
+	}
+	return doc.metadata, doc.body, nil
 }
 
 // ReadProperties reads skill properties from SKILL.md frontmatter.
@@ -65,43 +228,61 @@ func parseFrontmatter(content string) (metadata map[string]any, body string, err
 // It validates that required fields (name, description) exist but does NOT
 // perform full validation. Use Validate() for complete validation.
 func ReadProperties(skillDir string) (*SkillProperties, error) {
-	skillMD := findSkillMD(skillDir)
-	if skillMD == "" {
-		return nil, &ParseError{Path: skillDir, Err: ErrSkillMDNotFound}
-	}
+	return readProperties(osSkillFS(skillDir))
+}
 
-	content, err := os.ReadFile(skillMD)
+// ReadPropertiesFS is like ReadProperties but reads the skill directory dir
+// from fsys, such as an embed.FS, a zip.Reader or an fstest.MapFS.
+func ReadPropertiesFS(fsys fs.FS, dir string) (*SkillProperties, error) {
+	return readProperties(newSkillFS(fsys, dir))
+}
+
+func readProperties(s skillFS) (*SkillProperties, error) {
+	name, content, err := s.readSkillMD()
 	if err != nil {
-		return nil, &ParseError{Path: skillMD, Err: err}
+		return nil, err
 	}
 
-	metadata, _, err := parseFrontmatter(string(content))
+	doc, err := parseDocument(content, s.path(name))
 	if err != nil {
 		return nil, err
 	}
 
+	return propertiesFromMetadata(doc.metadata, doc.src)
+}
+
+// propertiesFromMetadata builds SkillProperties from parsed frontmatter.
+// It checks that the required fields are present and non-empty.
+func propertiesFromMetadata(metadata map[string]any, src *sourceMap) (*SkillProperties, error) {
 	// Check required fields
 	name, ok := metadata["name"]
 	if !ok {
-		return nil, &ValidationError{Field: "name", Err: ErrMissingName}
+		return nil, newValidationError("name", src.key("name"), ErrMissingName)
 	}
-	nameStr, ok := name.(string)
-	if !ok || strings.TrimSpace(nameStr) == "" {
-		return nil, &ValidationError{Field: "name", Err: ErrNameEmpty}
+	if nameStr, ok := name.(string); !ok || strings.TrimSpace(nameStr) == "" {
+		return nil, newValidationError("name", src.value("name"), ErrNameEmpty)
 	}
 
 	desc, ok := metadata["description"]
 	if !ok {
-		return nil, &ValidationError{Field: "description", Err: ErrMissingDescription}
+		return nil, newValidationError("description", src.key("description"), ErrMissingDescription)
 	}
-	descStr, ok := desc.(string)
-	if !ok || strings.TrimSpace(descStr) == "" {
-		return nil, &ValidationError{Field: "description", Err: ErrDescriptionEmpty}
+	if descStr, ok := desc.(string); !ok || strings.TrimSpace(descStr) == "" {
+		return nil, newValidationError("description", src.value("description"), ErrDescriptionEmpty)
 	}
 
-	props := &SkillProperties{
-		Name:        strings.TrimSpace(nameStr),
-		Description: strings.TrimSpace(descStr),
+	return collectProperties(metadata), nil
+}
+
+// collectProperties copies the known fields of parsed frontmatter into
+// SkillProperties, ignoring missing or mistyped values.
+func collectProperties(metadata map[string]any) *SkillProperties {
+	props := &SkillProperties{}
+	if v, ok := metadata["name"].(string); ok {
+		props.Name = strings.TrimSpace(v)
+	}
+	if v, ok := metadata["description"].(string); ok {
+		props.Description = strings.TrimSpace(v)
 	}
 
 	// Optional fields
@@ -118,5 +299,65 @@ func ReadProperties(skillDir string) (*SkillProperties, error) {
 		props.Metadata = v
 	}
 
-	return props, nil
+	return props
+}
+
+// ParseOptions controls Parse and ParseBytes.
+type ParseOptions struct {
+	// Dir is the skill directory the content belongs to, if any. When set,
+	// the skill name must match the directory name. When empty, as for
+	// content from an HTTP upload or a database row, that check is skipped.
+	Dir string
+
+	// Path is the file name reported in errors and diagnostic positions.
+	Path string
+}
+
+// ParseResult holds SKILL.md content parsed by Parse or ParseBytes.
+type ParseResult struct {
+	// Properties holds the known frontmatter fields. Fields that are
+	// missing or have the wrong type are left empty and reported in
+	// Diagnostics.
+	Properties *SkillProperties
+
+	// Body is the markdown content after the frontmatter.
+	Body string
+
+	// Frontmatter is the raw YAML text between the --- delimiters.
+	Frontmatter string
+
+	// Diagnostics lists every validation problem found in the frontmatter.
+	Diagnostics []error
+}
+
+// Err returns the diagnostics as a *ValidationErrors, or nil if there are none.
+func (r *ParseResult) Err() error {
+	return (&ValidationErrors{Errors: r.Diagnostics}).AsError()
+}
+
+// Parse reads SKILL.md content from r and validates its frontmatter.
+// The returned error is non-nil only if the content cannot be read or has
+// no parseable frontmatter; validation problems are reported in
+// ParseResult.Diagnostics.
+func Parse(r io.Reader, opts ParseOptions) (*ParseResult, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &ParseError{Path: opts.Path, Err: err}
+	}
+	return ParseBytes(content, opts)
+}
+
+// ParseBytes is like Parse but takes the SKILL.md content directly.
+func ParseBytes(content []byte, opts ParseOptions) (*ParseResult, error) {
+	doc, err := parseDocument(content, opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ParseResult{
+		Properties:  collectProperties(doc.metadata),
+		Body:        doc.body,
+		Frontmatter: doc.frontmatter,
+		Diagnostics: NewValidator().checkDocument(doc, opts.Dir, opts.Path),
+	}, nil
 }
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestParseFrontmatter_Valid(t *testing.T) {
@@ -206,3 +208,302 @@ func TestSkillProperties_ToMap(t *testing.T) {
 		t.Error("expected compatibility to be omitted when empty")
 	}
 }
+
+func TestReadPropertiesFS_MapFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"skills/my-skill/SKILL.md": {Data: []byte("---\nname: my-skill\ndescription: From memory\n---\nBody\n")},
+	}
+
+	props, err := ReadPropertiesFS(fsys, "skills/my-skill")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if props.Name != "my-skill" {
+		t.Errorf("expected name 'my-skill', got %q", props.Name)
+	}
+	if props.Description != "From memory" {
+		t.Errorf("expected description 'From memory', got %q", props.Description)
+	}
+}
+
+func TestReadPropertiesFS_LowercaseSkillMD(t *testing.T) {
+	fsys := fstest.MapFS{
+		"my-skill/skill.md": {Data: []byte("---\nname: my-skill\ndescription: Lowercase file\n---\n")},
+	}
+
+	props, err := ReadPropertiesFS(fsys, "my-skill")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if props.Name != "my-skill" {
+		t.Errorf("expected name 'my-skill', got %q", props.Name)
+	}
+}
+
+func TestReadPropertiesFS_MissingSkillMD(t *testing.T) {
+	fsys := fstest.MapFS{
+		"my-skill/README.md": {Data: []byte("# Not a skill")},
+	}
+
+	_, err := ReadPropertiesFS(fsys, "my-skill")
+	if !errors.Is(err, ErrSkillMDNotFound) {
+		t.Fatalf("expected ErrSkillMDNotFound, got %v", err)
+	}
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Path != "my-skill" {
+		t.Errorf("expected ParseError with path 'my-skill', got %v", err)
+	}
+}
+
+func TestReadPropertiesFS_DirFS(t *testing.T) {
+	props, err := ReadPropertiesFS(os.DirFS("testdata"), "valid-all-fields")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if props.License != "MIT" {
+		t.Errorf("expected license 'MIT', got %q", props.License)
+	}
+}
+
+func TestParse_Reader(t *testing.T) {
+	content := "---\nname: uploaded-skill\ndescription: From an upload\nlicense: MIT\n---\n# Uploaded\n"
+	result, err := Parse(strings.NewReader(content), ParseOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Properties.Name != "uploaded-skill" {
+		t.Errorf("expected name 'uploaded-skill', got %q", result.Properties.Name)
+	}
+	if result.Properties.License != "MIT" {
+		t.Errorf("expected license 'MIT', got %q", result.Properties.License)
+	}
+	if result.Body != "# Uploaded" {
+		t.Errorf("unexpected body: %q", result.Body)
+	}
+	if result.Err() != nil {
+		t.Errorf("expected nil Err, got %v", result.Err())
+	}
+}
+
+func TestParseBytes_DirectoryCheckOptional(t *testing.T) {
+	content := []byte("---\nname: my-skill\ndescription: A test skill\n---\n")
+
+	result, err := ParseBytes(content, ParseOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := result.Err(); err != nil {
+		t.Errorf("expected no errors without a directory, got %v", err)
+	}
+
+	result, err = ParseBytes(content, ParseOptions{Dir: "skills/other-name"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := result.Err(); err == nil || !strings.Contains(err.Error(), "must match skill name") {
+		t.Errorf("expected directory mismatch diagnostic, got %v", err)
+	}
+}
+
+func TestParseBytes_ReportsDiagnostics(t *testing.T) {
+	content := []byte("---\nname: Bad_Name\nextra: field\n---\nBody\n")
+
+	result, err := ParseBytes(content, ParseOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Properties.Name != "Bad_Name" {
+		t.Errorf("expected name to be kept, got %q", result.Properties.Name)
+	}
+	if result.Properties.Description != "" {
+		t.Errorf("expected empty description, got %q", result.Properties.Description)
+	}
+
+	err = result.Err()
+	if !errors.Is(err, ErrMissingDescription) {
+		t.Errorf("expected ErrMissingDescription in diagnostics, got %v", err)
+	}
+	for _, want := range []string{"lowercase", "invalid characters", "unexpected field"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q diagnostic, got %v", want, err)
+		}
+	}
+}
+
+func TestParseBytes_MissingFrontmatter(t *testing.T) {
+	_, err := ParseBytes([]byte("# Just markdown"), ParseOptions{})
+	if !errors.Is(err, ErrMissingFrontmatter) {
+		t.Errorf("expected ErrMissingFrontmatter, got %v", err)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("connection reset") }
+
+func TestParse_ReaderError(t *testing.T) {
+	_, err := Parse(failingReader{}, ParseOptions{})
+	if err == nil || !strings.Contains(err.Error(), "connection reset") {
+		t.Errorf("expected reader error, got %v", err)
+	}
+}
+
+func TestSplitFrontmatter_Delimiters(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		frontmatter string
+		body        string
+		err         error
+	}{
+		{
+			name:        "dashes inside value",
+			content:     "---\nname: my-skill\ndescription: before --- after\n---\nBody\n",
+			frontmatter: "name: my-skill\ndescription: before --- after\n",
+			body:        "Body",
+		},
+		{
+			name:        "dashes inside quoted string",
+			content:     "---\nname: my-skill\ndescription: \"a\n  ---b\"\n---\nBody\n",
+			frontmatter: "name: my-skill\ndescription: \"a\n  ---b\"\n",
+			body:        "Body",
+		},
+		{
+			name:        "CRLF line endings",
+			content:     "---\r\nname: my-skill\r\ndescription: A test\r\n---\r\nLine 1\r\nLine 2\r\n",
+			frontmatter: "name: my-skill\ndescription: A test\n",
+			body:        "Line 1\nLine 2",
+		},
+		{
+			name:        "byte order mark",
+			content:     "\ufeff---\nname: my-skill\n---\nBody",
+			frontmatter: "name: my-skill\n",
+			body:        "Body",
+		},
+		{
+			name:        "document end marker",
+			content:     "---\nname: my-skill\n...\nBody\n",
+			frontmatter: "name: my-skill\n",
+			body:        "Body",
+		},
+		{
+			name:        "horizontal rule in body",
+			content:     "---\nname: my-skill\n---\nIntro\n\n---\n\nMore\n",
+			frontmatter: "name: my-skill\n",
+			body:        "Intro\n\n---\n\nMore",
+		},
+		{
+			name:        "trailing whitespace on delimiter",
+			content:     "--- \nname: my-skill\n---\t\n",
+			frontmatter: "name: my-skill\n",
+		},
+		{
+			name:        "closing delimiter at end of input",
+			content:     "---\nname: my-skill\n---",
+			frontmatter: "name: my-skill\n",
+		},
+		{
+			name:    "four dashes is not a delimiter",
+			content: "----\nname: my-skill\n---\n",
+			err:     ErrMissingFrontmatter,
+		},
+		{
+			name:    "closing line with extra dashes",
+			content: "---\nname: my-skill\n----\nBody\n",
+			err:     ErrUnclosedFrontmatter,
+		},
+		{
+			name:    "empty content",
+			content: "",
+			err:     ErrMissingFrontmatter,
+		},
+		{
+			name:    "opening delimiter only",
+			content: "---",
+			err:     ErrUnclosedFrontmatter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frontmatter, body, err := splitFrontmatter(tt.content)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("expected %v, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if frontmatter != tt.frontmatter {
+				t.Errorf("frontmatter: expected %q, got %q", tt.frontmatter, frontmatter)
+			}
+			if body != tt.body {
+				t.Errorf("body: expected %q, got %q", tt.body, body)
+			}
+		})
+	}
+}
+
+func TestParseFrontmatter_DashesInDescription(t *testing.T) {
+	content := "---\nname: my-skill\ndescription: Converts A --- B\n---\nBody\n"
+	metadata, _, err := parseFrontmatter(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if metadata["description"] != "Converts A --- B" {
+		t.Errorf("unexpected description: %v", metadata["description"])
+	}
+}
+
+func FuzzParseFrontmatter(f *testing.F) {
+	seeds := []string{
+		"---\nname: my-skill\ndescription: A test skill\n---\nBody\n",
+		"---\r\nname: my-skill\r\n---\r\n",
+		"\ufeff---\nname: x\n...\n",
+		"---\nname: [invalid\n---\n",
+		"---\n---\n",
+		"----\n",
+		"---",
+		"",
+		"---\nmetadata:\n  a: 1\n  b: [1, 2]\n---\n",
+		"---\n- a\n- b\n---\n",
+		"---\n0: \xb7\xfd\xfd\n...",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, content string) {
+		frontmatter, body, err := splitFrontmatter(content)
+		if err != nil {
+			if frontmatter != "" || body != "" {
+				t.Errorf("expected empty results on error, got %q, %q", frontmatter, body)
+			}
+			return
+		}
+		if strings.Contains(frontmatter, "\r") || strings.Contains(body, "\r") {
+			t.Errorf("line endings not normalized: %q, %q", frontmatter, body)
+		}
+
+		doc, err := parseDocument([]byte(content), "")
+		if err != nil {
+			return
+		}
+		if doc.metadata == nil {
+			t.Error("expected metadata map when parsing succeeds")
+		}
+		for field, fp := range doc.src.fields {
+			for _, pos := range []Position{fp.key, fp.value} {
+				if pos.Offset < 0 || pos.Offset > pos.EndOffset || pos.EndOffset > len(content) {
+					t.Errorf("%s: range [%d, %d) outside content of length %d",
+						field, pos.Offset, pos.EndOffset, len(content))
+				}
+			}
+		}
+		_ = NewValidator().checkDocument(doc, "", "")
+	})
+}
@@ -0,0 +1,227 @@
+package agentskills
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Permissions decides whether a tool call may be approved without asking
+// the user, from the allowed-tools of the active skills and a deny list.
+//
+// A permission matches a call to its tool when:
+//
+//   - it has no pattern, as in "Read": any call matches;
+//   - its pattern ends in ":*", as in "Bash(git:*)": the arguments are the
+//     prefix before ":*", or start with it followed by a space, so
+//     "git status" matches but "gitk" does not;
+//   - its pattern contains "*" elsewhere, as in "Bash(git * --help)": each
+//     "*" matches any run of characters, including spaces and slashes;
+//   - otherwise the arguments equal the pattern.
+//
+// Arguments are compared after trimming surrounding whitespace. Shell
+// tools (Bash) are treated conservatively: runs of whitespace count as one
+// space, and a command with control operators, redirections, expansions,
+// quoting or escapes, such as "git status && rm -rf /" or `git "push"`,
+// only matches an allowed permission that spells it out exactly, and is
+// denied when a denied permission matches any of the commands in it. While
+// a denied permission with a pattern names the tool, such a command is
+// never approved, since the deny list cannot see what it runs.
+// Prefix patterns only see the command as written: a denied "Bash(git
+// push:*)" does not catch "env git push", "command git push" or
+// "/usr/bin/git push", so deny lists are a guard against mistakes rather
+// than a sandbox.
+//
+// The arguments of other tools are paths: they are cleaned with path.Clean
+// before matching, so "/tmp/../etc/shadow" is matched as "/etc/shadow", and
+// relative paths that climb out of their directory with ".." match no
+// permission with a pattern.
+type Permissions struct {
+	// Allow lists the calls that are approved.
+	Allow ToolPermissions
+
+	// Deny lists the calls that are never approved, even when Allow
+	// matches them.
+	Deny ToolPermissions
+}
+
+// shellTools lists the tools whose arguments are shell commands.
+var shellTools = []string{"Bash"}
+
+// shellOperators lists the shell syntax that chains, redirects, expands,
+// quotes or escapes commands, so that a command containing it may run
+// something other than it spells.
+var shellOperators = []string{";", "&", "|", "`", "$", ">", "<", "\n", "\r", "\"", "'", "\\", "{"}
+
+// SkillPermissions combines the allowed-tools of skills into the
+// permissions granted while they are active. It returns the error of
+// ParseAllowedTools for the first malformed field.
+//
+// Example:
+//
+//	perms, err := agentskills.SkillPermissions(active...)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	perms.Deny = append(perms.Deny, agentskills.ToolPermission{Tool: "Bash", Pattern: "git push:*"})
+//	if ok, reason := perms.Allows("Bash", "git status"); ok {
+//	    log.Printf("auto-approved: %s", reason)
+//	}
+func SkillPermissions(skills ...*SkillProperties) (*Permissions, error) {
+	p := &Permissions{}
+	for _, s := range skills {
+		ps, err := ParseAllowedTools(s.AllowedTools)
+		if err != nil {
+			return nil, fmt.Errorf("skill %s: %w", s.Name, err)
+		}
+		for _, perm := range ps {
+			if !slices.Contains(p.Allow, perm) {
+				p.Allow = append(p.Allow, perm)
+			}
+		}
+	}
+	return p, nil
+}
+
+// Allows reports whether a call to tool with args may be approved, and
+// why: the permission that allowed or denied it, or why none applied.
+func (p *Permissions) Allows(tool, args string) (bool, string) {
+	args = strings.TrimSpace(args)
+	if !isShellTool(tool) && escapesDir(args) && p.deniesPatterns(tool) {
+		return false, fmt.Sprintf("%s path climbs out of its directory with \"..\"; deny entries cannot be checked", tool)
+	}
+	for _, perm := range p.Deny {
+		if perm.Matches(tool, args) || isShellTool(tool) && slices.ContainsFunc(shellCommands(args), func(command string) bool {
+			return perm.Matches(tool, command)
+		}) {
+			return false, fmt.Sprintf("denied by %s", perm)
+		}
+	}
+	if isShellTool(tool) && isCompoundCommand(args) && p.deniesPatterns(tool) {
+		return false, fmt.Sprintf("%s command chains, redirects, expands, quotes or escapes; deny entries cannot be checked", tool)
+	}
+	for _, perm := range p.Allow {
+		if perm.Matches(tool, args) {
+			return true, fmt.Sprintf("allowed by %s", perm)
+		}
+	}
+	if isShellTool(tool) && isCompoundCommand(args) && p.allowsTool(tool) {
+		return false, fmt.Sprintf("%s command chains, redirects, expands, quotes or escapes; only an exact allowed-tools entry approves it", tool)
+	}
+	return false, fmt.Sprintf("no allowed-tools entry matches %s", ToolPermission{Tool: tool, Pattern: args})
+}
+
+// deniesPatterns reports whether any denied permission with a pattern
+// names tool.
+func (p *Permissions) deniesPatterns(tool string) bool {
+	return slices.ContainsFunc(p.Deny, func(perm ToolPermission) bool {
+		return perm.Tool == tool && perm.Pattern != ""
+	})
+}
+
+// allowsTool reports whether any allowed permission names tool.
+func (p *Permissions) allowsTool(tool string) bool {
+	for _, perm := range p.Allow {
+		if perm.Tool == tool {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether p matches a call to tool with args, as
+// described for Permissions. Surrounding whitespace in args is ignored.
+func (p ToolPermission) Matches(tool, args string) bool {
+	if p.Tool != tool {
+		return false
+	}
+	if p.Pattern == "" {
+		return true
+	}
+	args = strings.TrimSpace(args)
+	pattern := strings.TrimSpace(p.Pattern)
+	if isShellTool(tool) {
+		args = strings.Join(strings.Fields(args), " ")
+		pattern = strings.Join(strings.Fields(pattern), " ")
+	} else {
+		if escapesDir(args) {
+			return false
+		}
+		args, pattern = cleanPath(args), cleanPath(pattern)
+	}
+	if args == pattern {
+		return true
+	}
+	if isShellTool(tool) && isCompoundCommand(args) {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(pattern, ":*"); ok {
+		return args == prefix || strings.HasPrefix(args, prefix+" ")
+	}
+	return strings.Contains(pattern, "*") && matchGlob(pattern, args)
+}
+
+// matchGlob reports whether s matches pattern, in which each "*" matches
+// any run of characters. pattern contains at least one "*".
+func matchGlob(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, last)
+}
+
+// cleanPath returns path.Clean(p), leaving alone empty arguments and URLs,
+// whose "//" is not a doubled separator.
+func cleanPath(p string) string {
+	if p == "" || strings.Contains(p, "://") {
+		return p
+	}
+	return path.Clean(p)
+}
+
+// escapesDir reports whether the path p still starts with ".." once
+// cleaned, so that what it names depends on the working directory.
+func escapesDir(p string) bool {
+	p = cleanPath(p)
+	return p == ".." || strings.HasPrefix(p, "../")
+}
+
+func isShellTool(tool string) bool {
+	return slices.Contains(shellTools, tool)
+}
+
+// shellCommands splits a shell command at shellOperators and parentheses
+// into the simple commands it may run.
+func shellCommands(command string) []string {
+	var commands []string
+	for _, c := range strings.FieldsFunc(command, func(r rune) bool {
+		return strings.ContainsRune(";&|`$()<>\n\r", r)
+	}) {
+		if c = strings.TrimSpace(c); c != "" {
+			commands = append(commands, c)
+		}
+	}
+	return commands
+}
+
+// isCompoundCommand reports whether a shell command contains
+// shellOperators, erring on the side of asking.
+func isCompoundCommand(command string) bool {
+	for _, op := range shellOperators {
+		if strings.Contains(command, op) {
+			return true
+		}
+	}
+	return false
+}
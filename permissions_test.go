@@ -0,0 +1,127 @@
+package agentskills
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPermissions_Allows(t *testing.T) {
+	p, err := SkillPermissions(
+		&SkillProperties{Name: "git", AllowedTools: "Bash(git:*) Read"},
+		&SkillProperties{Name: "docs", AllowedTools: "Bash(npm run docs:*) Bash(git:*) Edit(docs/*.md) WebFetch(domain:example.com)"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Allow) != 5 {
+		t.Errorf("expected duplicate permissions to be merged, got %v", p.Allow)
+	}
+	p.Deny = ToolPermissions{{"Bash", "git push:*"}, {"Read", "*.env"}, {"Read", "/etc/*"}}
+
+	tests := []struct {
+		tool, args string
+		want       bool
+		reason     string
+	}{
+		{"Bash", "git status", true, "allowed by Bash(git:*)"},
+		{"Bash", "  git  ", true, "allowed by Bash(git:*)"},
+		{"Bash", "gitk", false, "no allowed-tools entry matches Bash(gitk)"},
+		{"Bash", "git push origin main", false, "denied by Bash(git push:*)"},
+		{"Bash", "npm run docs -- --watch", true, "allowed by Bash(npm run docs:*)"},
+		{"Bash", "npm run build", false, "no allowed-tools entry matches"},
+		{"Bash", "git status && rm -rf /", false, "chains, redirects, expands, quotes or escapes"},
+		{"Bash", "git log > /etc/passwd", false, "chains, redirects, expands, quotes or escapes"},
+		{"Bash", "git log $(curl evil)", false, "chains, redirects, expands, quotes or escapes"},
+		{"Bash", "echo hi; git push", false, "denied by Bash(git push:*)"},
+		{"Bash", "git  push --force", false, "denied by Bash(git push:*)"},
+		{"Bash", "git\tpush", false, "denied by Bash(git push:*)"},
+		{"Bash", `git "push" origin`, false, "chains, redirects, expands, quotes or escapes"},
+		{"Bash", `git 'push' origin`, false, "chains, redirects, expands, quotes or escapes"},
+		{"Bash", `git pu\sh`, false, "chains, redirects, expands, quotes or escapes"},
+		{"Bash", "git push$IFS--force", false, "denied by Bash(git push:*)"},
+		{"Bash", "git {push,--force}", false, "chains, redirects, expands, quotes or escapes"},
+		{"Read", "src/main.go", true, "allowed by Read"},
+		{"Read", "config/.env", false, "denied by Read(*.env)"},
+		{"Read", "/tmp/../etc/shadow", false, "denied by Read(/etc/*)"},
+		{"Read", "//etc/shadow", false, "denied by Read(/etc/*)"},
+		{"Read", "../../etc/shadow", false, "deny entries cannot be checked"},
+		{"Edit", "docs/guide/intro.md", true, "allowed by Edit(docs/*.md)"},
+		{"Edit", "./docs/intro.md", true, "allowed by Edit(docs/*.md)"},
+		{"Edit", "docs/../../etc/x.md", false, "no allowed-tools entry matches"},
+		{"Edit", "src/docs/intro.md", false, "no allowed-tools entry matches"},
+		{"WebFetch", "domain:example.com", true, "allowed by WebFetch(domain:example.com)"},
+		{"WebFetch", "domain:example.org", false, "no allowed-tools entry matches"},
+		{"bash", "git status", false, "no allowed-tools entry matches"},
+		{"Write", "a.txt", false, "no allowed-tools entry matches Write(a.txt)"},
+	}
+	for _, tt := range tests {
+		got, reason := p.Allows(tt.tool, tt.args)
+		if got != tt.want || !strings.Contains(reason, tt.reason) {
+			t.Errorf("Allows(%q, %q) = %v, %q; want %v, %q", tt.tool, tt.args, got, reason, tt.want, tt.reason)
+		}
+	}
+
+	// An unrestricted tool still cannot run what the deny list cannot see.
+	bare := &Permissions{Allow: ToolPermissions{{"Bash", ""}}, Deny: ToolPermissions{{"Bash", "git push:*"}}}
+	for _, tt := range []struct {
+		args   string
+		want   bool
+		reason string
+	}{
+		{"git status", true, "allowed by Bash"},
+		{"git push origin", false, "denied by Bash(git push:*)"},
+		{`git "push" origin`, false, "deny entries cannot be checked"},
+		{`git pu\sh`, false, "deny entries cannot be checked"},
+		{"echo a | wc -l", false, "deny entries cannot be checked"},
+	} {
+		got, reason := bare.Allows("Bash", tt.args)
+		if got != tt.want || !strings.Contains(reason, tt.reason) {
+			t.Errorf("unrestricted Bash: Allows(%q) = %v, %q; want %v, %q", tt.args, got, reason, tt.want, tt.reason)
+		}
+	}
+	bare.Deny = nil
+	if ok, _ := bare.Allows("Bash", `git "push" origin`); !ok {
+		t.Error("expected unrestricted Bash without a deny list to allow any command")
+	}
+}
+
+func TestToolPermission_Matches(t *testing.T) {
+	tests := []struct {
+		perm ToolPermission
+		args string
+		want bool
+	}{
+		{ToolPermission{"Bash", ""}, "anything && more", true},
+		{ToolPermission{"Bash", "git status"}, "git status", true},
+		{ToolPermission{"Bash", "git status"}, "git status -s", false},
+		{ToolPermission{"Bash", "echo a | wc -l"}, "echo a | wc -l", true},
+		{ToolPermission{"Bash", "git * --help"}, "git commit --help", true},
+		{ToolPermission{"Bash", "git * --help"}, "git commit --help | sh", false},
+		{ToolPermission{"Bash", "git*"}, "gitk", true},
+		{ToolPermission{"Read", "*"}, "a/b/c", true},
+		{ToolPermission{"Read", "a*b*b"}, "abb", true},
+		{ToolPermission{"Read", "a*b*b"}, "ab", false},
+		{ToolPermission{"Read", "src/**/*.go"}, "src/x/y.go", true},
+		{ToolPermission{"Read", "src/**/*.go"}, "src/y.go", false},
+		{ToolPermission{"Read", "x:*"}, "x ; y", true},
+		{ToolPermission{"Bash", "git  log:*"}, "git log  -p", true},
+		{ToolPermission{"Bash", `git "log"`}, `git "log"`, true},
+		{ToolPermission{"Edit", "docs/*.md"}, "docs/../../etc/x.md", false},
+		{ToolPermission{"Read", "/tmp/*"}, "/tmp/../etc/shadow", false},
+		{ToolPermission{"Read", "../*"}, "../x", false},
+		{ToolPermission{"WebFetch", "https://example.com/*"}, "https://example.com/a", true},
+	}
+	for _, tt := range tests {
+		if got := tt.perm.Matches(tt.perm.Tool, tt.args); got != tt.want {
+			t.Errorf("%s matches %q = %v, want %v", tt.perm, tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestSkillPermissions_Invalid(t *testing.T) {
+	_, err := SkillPermissions(&SkillProperties{Name: "bad", AllowedTools: "Bash(git:*"})
+	if !errors.Is(err, ErrAllowedToolsInvalid) || !strings.Contains(err.Error(), "skill bad") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,271 @@
+package agentskills
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/goccy/go-yaml/token"
+)
+
+// Position identifies a location in a SKILL.md file.
+// Line and Column are 1-based, and Column counts bytes. Offset and EndOffset
+// delimit the byte range of the reported construct in the original file,
+// counting any byte order mark and CR characters.
+type Position struct {
+	File      string
+	Line      int
+	Column    int
+	Offset    int
+	EndOffset int
+}
+
+// IsValid reports whether the position carries a line number.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String returns the position as "file:line:column", omitting unknown parts.
+func (p Position) String() string {
+	if !p.IsValid() {
+		return p.File
+	}
+	if p.File == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// fieldPos records where a frontmatter field appears.
+type fieldPos struct {
+	key   Position
+	value Position
+}
+
+// sourceMap translates locations within the frontmatter into positions in
+// the original SKILL.md file. The frontmatter always starts on line 2,
+// after the opening "---" delimiter. All methods accept a nil *sourceMap
+// and then return zero positions.
+type sourceMap struct {
+	file       string
+	bom        int      // length of a leading byte order mark
+	lineStarts []int    // byte offset of each line in the original content
+	lines      []string // normalized frontmatter lines
+	fields     map[string]fieldPos
+}
+
+// newSourceMap records the line structure of content read from file.
+func newSourceMap(file string, content []byte) *sourceMap {
+	m := &sourceMap{file: file, lineStarts: []int{0}}
+	if strings.HasPrefix(string(content), "\ufeff") {
+		m.bom = len("\ufeff")
+	}
+	for i := 0; i < len(content); i++ {
+		switch content[i] {
+		case '\r':
+			if i+1 < len(content) && content[i+1] == '\n' {
+				i++
+			}
+			m.lineStarts = append(m.lineStarts, i+1)
+		case '\n':
+			m.lineStarts = append(m.lineStarts, i+1)
+		}
+	}
+	return m
+}
+
+// delimiter returns the position of the opening "---" line.
+func (m *sourceMap) delimiter() Position {
+	if m == nil {
+		return Position{}
+	}
+	return Position{File: m.file, Line: 1, Column: 1, Offset: m.bom, EndOffset: m.bom + len("---")}
+}
+
+// at returns the position of byte column col on frontmatter line line.
+func (m *sourceMap) at(line, col int) Position {
+	if m == nil {
+		return Position{}
+	}
+	fileLine := line + 1
+	if line < 1 || fileLine > len(m.lineStarts) {
+		return Position{File: m.file}
+	}
+	if line <= len(m.lines) {
+		// Invalid UTF-8 is widened by the YAML parser; stay on the line.
+		col = min(max(col, 1), len(m.lines[line-1])+1)
+	}
+	offset := m.lineStarts[fileLine-1] + col - 1
+	return Position{File: m.file, Line: fileLine, Column: col, Offset: offset, EndOffset: offset}
+}
+
+// span returns the position from (line, col) up to (endLine, endCol), with
+// byte columns on frontmatter lines.
+func (m *sourceMap) span(line, col, endLine, endCol int) Position {
+	pos := m.at(line, col)
+	if end := m.at(endLine, endCol); end.IsValid() {
+		pos.EndOffset = end.Offset
+	}
+	return pos
+}
+
+// byteColumn converts the 1-based rune column reported by the YAML parser
+// into a 1-based byte column on frontmatter line line.
+func (m *sourceMap) byteColumn(line, runeCol int) int {
+	if line < 1 || line > len(m.lines) {
+		return runeCol
+	}
+	text := m.lines[line-1]
+	col := 1
+	for i := 1; i < runeCol && col <= len(text); i++ {
+		_, size := utf8.DecodeRuneInString(text[col-1:])
+		col += size
+	}
+	return col
+}
+
+// tokenColumn returns the frontmatter line and byte column of tk.
+func (m *sourceMap) tokenColumn(tk *token.Token) (line, col int) {
+	return tk.Position.Line, m.byteColumn(tk.Position.Line, tk.Position.Column)
+}
+
+// key returns the position of the key of field, which may be a dotted path
+// such as "metadata.author". If the field is absent, it returns the
+// position of the opening delimiter.
+func (m *sourceMap) key(field string) Position {
+	if m == nil {
+		return Position{}
+	}
+	if fp, ok := m.fields[field]; ok {
+		return fp.key
+	}
+	return m.delimiter()
+}
+
+// value returns the position of the value of field, falling back to the
+// key position when the value is empty.
+func (m *sourceMap) value(field string) Position {
+	if m == nil {
+		return Position{}
+	}
+	if fp, ok := m.fields[field]; ok {
+		return fp.value
+	}
+	return m.delimiter()
+}
+
+// annotate fills in the file path and position of a *ParseError.
+func (m *sourceMap) annotate(err error) error {
+	var pe *ParseError
+	if m == nil || !errors.As(err, &pe) {
+		return err
+	}
+	if pe.Path == "" {
+		pe.Path = m.file
+	}
+	if pe.Pos.IsValid() {
+		return err
+	}
+
+	pe.Pos = m.delimiter()
+	var yamlErr yaml.Error
+	if errors.As(err, &yamlErr) && yamlErr.GetToken() != nil {
+		line, col := m.tokenColumn(yamlErr.GetToken())
+		pe.Pos = m.at(line, col)
+	}
+	return err
+}
+
+// indexFrontmatter records the positions of the top-level fields of the
+// frontmatter and of the entries nested directly below them.
+func (m *sourceMap) indexFrontmatter(frontmatter string) {
+	m.lines = strings.Split(frontmatter, "\n")
+	m.fields = make(map[string]fieldPos)
+
+	file, err := parser.ParseBytes([]byte(frontmatter), 0)
+	if err != nil || len(file.Docs) == 0 {
+		return
+	}
+	if mapping, ok := file.Docs[0].Body.(*ast.MappingNode); ok {
+		m.indexMapping(mapping, "", len(m.lines)+1)
+	}
+}
+
+// indexMapping records the entries of mapping, whose last line is before
+// endLine, under keys prefixed with prefix.
+func (m *sourceMap) indexMapping(mapping *ast.MappingNode, prefix string, endLine int) {
+	for i, mv := range mapping.Values {
+		keyTk := mv.Key.GetToken()
+		if keyTk == nil {
+			continue
+		}
+		nextLine := endLine
+		if i+1 < len(mapping.Values) {
+			if next := mapping.Values[i+1].Key.GetToken(); next != nil {
+				nextLine = next.Position.Line
+			}
+		}
+
+		name := prefix + keyTk.Value
+		keyLine, keyCol := m.tokenColumn(keyTk)
+		keyText := strings.TrimSpace(keyTk.Origin)
+		if keyText == "" {
+			keyText = keyTk.Value
+		}
+		keyPos := m.span(keyLine, keyCol, keyLine, keyCol+len(keyText))
+
+		m.fields[name] = fieldPos{key: keyPos, value: m.valuePos(mv, keyPos, nextLine)}
+
+		if nested, ok := mv.Value.(*ast.MappingNode); ok && prefix == "" {
+			m.indexMapping(nested, name+".", nextLine)
+		}
+	}
+}
+
+// valuePos returns the position of the value of mv, whose entry ends
+// before nextLine.
+func (m *sourceMap) valuePos(mv *ast.MappingValueNode, keyPos Position, nextLine int) Position {
+	var startTk *token.Token
+	switch v := mv.Value.(type) {
+	case *ast.NullNode:
+		return keyPos
+	case *ast.MappingNode:
+		if len(v.Values) == 0 || v.IsFlowStyle {
+			startTk = v.GetToken()
+		} else {
+			startTk = v.Values[0].Key.GetToken()
+		}
+	default:
+		startTk = v.GetToken()
+	}
+	if startTk == nil {
+		return keyPos
+	}
+
+	line, col := m.tokenColumn(startTk)
+
+	// Single-line scalars end where their source text ends.
+	if _, ok := mv.Value.(ast.ScalarNode); ok {
+		if text := strings.TrimSpace(startTk.Origin); text != "" && !strings.Contains(text, "\n") {
+			if _, isLiteral := mv.Value.(*ast.LiteralNode); !isLiteral {
+				return m.span(line, col, line, col+len(text))
+			}
+		}
+	}
+
+	// Otherwise the value ends with the last content line of the entry.
+	endLine, endCol := line, col
+	for l := line; l < nextLine && l <= len(m.lines); l++ {
+		text := strings.TrimRight(m.lines[l-1], " \t")
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		endLine, endCol = l, len(text)+1
+	}
+	return m.span(line, col, endLine, endCol)
+}
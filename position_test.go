@@ -0,0 +1,195 @@
+package agentskills
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// spanText returns the text of content covered by pos.
+func spanText(content string, pos Position) string {
+	if pos.Offset < 0 || pos.EndOffset > len(content) || pos.Offset > pos.EndOffset {
+		return "<invalid range>"
+	}
+	return content[pos.Offset:pos.EndOffset]
+}
+
+func TestSourceMap_FieldPositions(t *testing.T) {
+	content := "---\n" +
+		"# leading comment\n" +
+		"name: héllo-skill\n" +
+		"description: \"Quoted: value\"\n" +
+		"license: |\n" +
+		"  line one\n" +
+		"  line two\n" +
+		"\n" +
+		"metadata:\n" +
+		"  author: Jane\n" +
+		"  version: '1.0'\n" +
+		"empty:\n" +
+		"---\n" +
+		"Body\n"
+
+	doc, err := parseDocument([]byte(content), "SKILL.md")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		field string
+		key   bool
+		line  int
+		col   int
+		text  string
+	}{
+		{"name", true, 3, 1, "name"},
+		{"name", false, 3, 7, "héllo-skill"},
+		{"description", false, 4, 14, `"Quoted: value"`},
+		{"license", false, 5, 10, "|\n  line one\n  line two"},
+		{"metadata", false, 10, 3, "author: Jane\n  version: '1.0'"},
+		{"metadata.author", true, 10, 3, "author"},
+		{"metadata.author", false, 10, 11, "Jane"},
+		{"metadata.version", false, 11, 12, "'1.0'"},
+		{"empty", false, 12, 1, "empty"},
+	}
+
+	for _, tt := range tests {
+		pos := doc.src.value(tt.field)
+		if tt.key {
+			pos = doc.src.key(tt.field)
+		}
+		if pos.File != "SKILL.md" || pos.Line != tt.line || pos.Column != tt.col {
+			t.Errorf("%s (key=%v): expected SKILL.md:%d:%d, got %s", tt.field, tt.key, tt.line, tt.col, pos)
+		}
+		if got := spanText(content, pos); got != tt.text {
+			t.Errorf("%s (key=%v): expected text %q, got %q", tt.field, tt.key, tt.text, got)
+		}
+	}
+}
+
+func TestSourceMap_CRLFAndBOM(t *testing.T) {
+	content := "\ufeff---\r\nname: my-skill\r\ndescription: A test\r\n---\r\nBody\r\n"
+
+	doc, err := parseDocument([]byte(content), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pos := doc.src.value("description")
+	if pos.Line != 3 || pos.Column != 14 {
+		t.Errorf("expected 3:14, got %s", pos)
+	}
+	if got := spanText(content, pos); got != "A test" {
+		t.Errorf("expected original byte range to cover 'A test', got %q", got)
+	}
+
+	if got := spanText(content, doc.src.delimiter()); got != "---" {
+		t.Errorf("expected delimiter range to skip the BOM, got %q", got)
+	}
+}
+
+func TestParseError_Positions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     error
+		line    int
+	}{
+		{"missing frontmatter", "# Title\n", ErrMissingFrontmatter, 1},
+		{"unclosed frontmatter", "---\nname: x\n", ErrUnclosedFrontmatter, 1},
+		{"invalid YAML", "---\nname: ok\ndescription: [broken\nlicense: MIT\n---\n", ErrInvalidYAML, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDocument([]byte(tt.content), "skills/x/SKILL.md")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expected *ParseError, got %T", err)
+			}
+			if parseErr.Pos.Line != tt.line || parseErr.Pos.File != "skills/x/SKILL.md" {
+				t.Errorf("expected skills/x/SKILL.md line %d, got %s", tt.line, parseErr.Pos)
+			}
+			if !strings.HasPrefix(err.Error(), parseErr.Pos.String()+": ") {
+				t.Errorf("expected error to start with position, got %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestValidate_DiagnosticPositions(t *testing.T) {
+	tmpDir := t.TempDir()
+	skillDir := filepath.Join(tmpDir, "my-skill")
+	if err := os.Mkdir(skillDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	longDesc := strings.Repeat("x", 1100)
+	content := "---\nname: my-skill\ndescription: " + longDesc + "\nextra: field\n---\nBody\n"
+	skillMD := filepath.Join(skillDir, "SKILL.md")
+	if err := os.WriteFile(skillMD, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Validate(skillDir)
+	var verrs *ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("expected *ValidationErrors, got %v", err)
+	}
+
+	found := map[string]Position{}
+	for _, e := range verrs.Errors {
+		var ve *ValidationError
+		if !errors.As(e, &ve) {
+			t.Fatalf("expected *ValidationError, got %T", e)
+		}
+		found[ve.Field] = ve.Pos
+	}
+
+	desc := found["description"]
+	if desc.File != skillMD || desc.Line != 3 || desc.Column != 14 {
+		t.Errorf("unexpected description position: %s", desc)
+	}
+	if spanText(content, desc) != longDesc {
+		t.Error("expected description range to cover the value")
+	}
+
+	extra := found["extra"]
+	if extra.Line != 4 || spanText(content, extra) != "extra" {
+		t.Errorf("unexpected extra field position: %s", extra)
+	}
+}
+
+func TestReadProperties_MissingNamePosition(t *testing.T) {
+	_, err := ReadProperties("testdata/missing-name")
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	expected := filepath.Join("testdata", "missing-name", "SKILL.md")
+	if ve.Pos.File != expected || ve.Pos.Line != 1 {
+		t.Errorf("expected %s:1, got %s", expected, ve.Pos)
+	}
+}
+
+func TestPosition_String(t *testing.T) {
+	tests := []struct {
+		pos  Position
+		want string
+	}{
+		{Position{}, ""},
+		{Position{File: "a/SKILL.md"}, "a/SKILL.md"},
+		{Position{Line: 3, Column: 7}, "3:7"},
+		{Position{File: "a/SKILL.md", Line: 3, Column: 7}, "a/SKILL.md:3:7"},
+	}
+	for _, tt := range tests {
+		if got := tt.pos.String(); got != tt.want {
+			t.Errorf("%+v: expected %q, got %q", tt.pos, tt.want, got)
+		}
+	}
+}
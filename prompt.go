@@ -1,8 +1,11 @@
 package agentskills
 
 import (
-	"html"
+	"cmp"
+	"fmt"
+	"io/fs"
 	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -10,52 +13,328 @@ import (
 //
 // This XML format is what Anthropic uses and recommends for Claude models.
 // Skill Clients may format skill information differently to suit their
-// models or preferences.
+// models or preferences; use ToPromptWith to choose another PromptRenderer,
+// such as XMLRenderer with the XMLPretty or XMLCompact layout.
+// Locations are absolute paths on the host; use GeneratePrompt with
+// PromptOptions.Location to show them relative to a directory, as seen from
+// a container, as file:// URIs, or not at all.
 //
 // Example output:
 //
 //	<available_skills>
 //	<skill>
-//	<name>pdf-reader</name>
-//	<description>Read and extract text from PDF files</description>
-//	<location>/path/to/pdf-reader/SKILL.md</location>
+//	<name>
+//	pdf-reader
+//	</name>
+//	<description>
+//	Read and extract text from PDF files
+//	</description>
+//	<location>
+//	/path/to/pdf-reader/SKILL.md
+//	</location>
 //	</skill>
 //	</available_skills>
 func ToPrompt(skillDirs []string) (string, error) {
-	if len(skillDirs) == 0 {
-		return "<available_skills>\n</available_skills>", nil
+	return ToPromptWith(skillDirs, XMLRenderer{})
+}
+
+// ToPromptFS is like ToPrompt but reads the skill directories from fsys.
+// Locations are reported as slash-separated paths within fsys.
+func ToPromptFS(fsys fs.FS, skillDirs []string) (string, error) {
+	return ToPromptFSWith(fsys, skillDirs, XMLRenderer{})
+}
+
+// ToPromptWith is like ToPrompt but formats the skills with r.
+//
+// Example:
+//
+//	prompt, err := agentskills.ToPromptWith(dirs, agentskills.MarkdownRenderer{})
+func ToPromptWith(skillDirs []string, r PromptRenderer) (string, error) {
+	skills, err := PromptSkills(skillDirs)
+	if err != nil {
+		return "", err
+	}
+	return r.Render(skills)
+}
+
+// ToPromptFSWith is like ToPromptFS but formats the skills with r.
+func ToPromptFSWith(fsys fs.FS, skillDirs []string, r PromptRenderer) (string, error) {
+	skills, err := PromptSkillsFS(fsys, skillDirs)
+	if err != nil {
+		return "", err
+	}
+	return r.Render(skills)
+}
+
+func promptSkills(skills []skillFS) ([]PromptSkill, error) {
+	entries := make([]PromptSkill, 0, len(skills))
+	for _, s := range skills {
+		props, err := readProperties(s)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, PromptSkill{SkillProperties: *props, Location: s.path(s.findSkillMD())})
+	}
+	return entries, nil
+}
+
+// PromptOptions controls GeneratePrompt and RenderPrompt.
+type PromptOptions struct {
+	// Renderer formats the skills. The zero value uses XMLRenderer.
+	Renderer PromptRenderer
+
+	// MaxTokens is the token budget of the prompt; zero means no limit.
+	// When the prompt would exceed it, skills are cut lowest priority
+	// first, until it fits: among the skills of the lowest priority,
+	// descriptions are shortened and then skills dropped, before any skill
+	// of a higher priority is touched. RenderPrompt fails if the prompt
+	// exceeds the budget even without skills.
+	MaxTokens int
+
+	// Location rewrites the location of each skill, for example with
+	// RelativeLocation, MapLocation, FileURILocation or OmitLocation. If
+	// nil, locations are shown as PromptSkills reports them: absolute paths
+	// on the host.
+	Location LocationFunc
+
+	// Environment, if set, leaves out the skills whose structured
+	// compatibility rules it out; see Compatibility. Skills with
+	// free-text or malformed compatibility are kept.
+	Environment *Environment
+
+	// Tokenizer counts prompt tokens. The zero value uses ApproxTokenizer.
+	Tokenizer Tokenizer
+
+	// Priority ranks skills: higher priorities come first in the prompt and
+	// are cut last. Skills of equal priority keep their order. If nil, all
+	// skills have the same priority.
+	Priority func(s PromptSkill) int
+
+	// MinDescription is the number of characters descriptions may be
+	// shortened to before skills are dropped. The zero value uses
+	// DefaultMinDescription.
+	MinDescription int
+}
+
+// DefaultMinDescription is the default PromptOptions.MinDescription.
+const DefaultMinDescription = 80
+
+// PromptResult is a prompt generated with PromptOptions.
+type PromptResult struct {
+	Prompt string
+
+	// Tokens is the token count of Prompt.
+	Tokens int
+
+	// Skills lists the skills in the prompt, in prompt order, with their
+	// locations as shown in the prompt.
+	Skills []PromptSkill
+
+	// Omissions reports the skills that were shortened or dropped to fit
+	// the token budget, in the order the cuts were made.
+	Omissions []PromptOmission
+
+	// Exclusions reports the skills left out because they cannot run in
+	// PromptOptions.Environment, in their original order.
+	Exclusions []PromptExclusion
+}
+
+// PromptExclusion records a skill left out because of its compatibility.
+type PromptExclusion struct {
+	Name     string
+	Location string
+
+	// Reasons explains why the skill cannot run, such as
+	// "requires python >=3.11, found python 3.9.6".
+	Reasons []string
+}
+
+// PromptOmission records a cut made to fit the token budget.
+type PromptOmission struct {
+	Name string
+
+	// Location is the location of the skill before PromptOptions.Location
+	// rewrites it.
+	Location string
+
+	// Dropped reports whether the skill was left out of the prompt;
+	// otherwise its description was shortened.
+	Dropped bool
+}
+
+// GeneratePrompt is like ToPromptWith but applies opts, and reports what
+// was left out to fit the token budget or the environment.
+//
+// Example:
+//
+//	result, err := agentskills.GeneratePrompt(dirs, agentskills.PromptOptions{MaxTokens: 4000})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, o := range result.Omissions {
+//	    log.Printf("%s: dropped=%v", o.Name, o.Dropped)
+//	}
+func GeneratePrompt(skillDirs []string, opts PromptOptions) (*PromptResult, error) {
+	skills, err := PromptSkills(skillDirs)
+	if err != nil {
+		return nil, err
 	}
+	return RenderPrompt(skills, opts)
+}
 
-	var lines []string
-	lines = append(lines, "<available_skills>")
+// GeneratePromptFS is like GeneratePrompt but reads the skill directories
+// from fsys.
+func GeneratePromptFS(fsys fs.FS, skillDirs []string, opts PromptOptions) (*PromptResult, error) {
+	skills, err := PromptSkillsFS(fsys, skillDirs)
+	if err != nil {
+		return nil, err
+	}
+	return RenderPrompt(skills, opts)
+}
 
+// PromptSkills reads the properties and locations of the skills in
+// skillDirs, as ToPrompt reports them.
+func PromptSkills(skillDirs []string) ([]PromptSkill, error) {
+	skills := make([]skillFS, 0, len(skillDirs))
 	for _, skillDir := range skillDirs {
 		absDir, err := filepath.Abs(skillDir)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
+		skills = append(skills, osSkillFS(absDir))
+	}
+	return promptSkills(skills)
+}
 
-		props, err := ReadProperties(absDir)
-		if err != nil {
-			return "", err
+// PromptSkillsFS is like PromptSkills but reads the skill directories from
+// fsys.
+func PromptSkillsFS(fsys fs.FS, skillDirs []string) ([]PromptSkill, error) {
+	skills := make([]skillFS, 0, len(skillDirs))
+	for _, skillDir := range skillDirs {
+		skills = append(skills, newSkillFS(fsys, skillDir))
+	}
+	return promptSkills(skills)
+}
+
+// RenderPrompt renders skills with opts.
+func RenderPrompt(skills []PromptSkill, opts PromptOptions) (*PromptResult, error) {
+	r := opts.Renderer
+	if r == nil {
+		r = XMLRenderer{}
+	}
+	tok := opts.Tokenizer
+	if tok == nil {
+		tok = ApproxTokenizer{}
+	}
+	minDesc := opts.MinDescription
+	if minDesc <= 0 {
+		minDesc = DefaultMinDescription
+	}
+
+	result := &PromptResult{}
+	if opts.Environment != nil {
+		var compatible []PromptSkill
+		for _, s := range skills {
+			if reasons, _ := CheckCompatibility(s.Compatibility, opts.Environment); len(reasons) > 0 {
+				result.Exclusions = append(result.Exclusions, PromptExclusion{Name: s.Name, Location: s.Location, Reasons: reasons})
+				continue
+			}
+			compatible = append(compatible, s)
 		}
+		skills = compatible
+	}
 
-		skillMDPath := findSkillMD(absDir)
+	skills = slices.Clone(skills)
+	priorities := make([]int, len(skills))
+	if opts.Priority != nil {
+		priority := make([]int, len(skills))
+		order := make([]int, len(skills))
+		for i, s := range skills {
+			priority[i], order[i] = opts.Priority(s), i
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			return cmp.Compare(priority[b], priority[a])
+		})
+		sorted := make([]PromptSkill, len(skills))
+		for i, k := range order {
+			sorted[i], priorities[i] = skills[k], priority[k]
+		}
+		skills = sorted
+	}
 
-		lines = append(lines, "<skill>")
-		lines = append(lines, "<name>")
-		lines = append(lines, html.EscapeString(props.Name))
-		lines = append(lines, "</name>")
-		lines = append(lines, "<description>")
-		lines = append(lines, html.EscapeString(props.Description))
-		lines = append(lines, "</description>")
-		lines = append(lines, "<location>")
-		lines = append(lines, skillMDPath)
-		lines = append(lines, "</location>")
-		lines = append(lines, "</skill>")
+	locations := make([]string, len(skills))
+	for i, s := range skills {
+		locations[i] = s.Location
+		if opts.Location != nil {
+			loc, err := opts.Location(s.Location)
+			if err != nil {
+				return nil, err
+			}
+			locations[i] = loc
+		}
+	}
+
+	render := func() (bool, error) {
+		shown := slices.Clone(skills)
+		for i := range shown {
+			shown[i].Location = locations[i]
+		}
+		prompt, err := r.Render(shown)
+		if err != nil {
+			return false, err
+		}
+		result.Prompt, result.Tokens, result.Skills = prompt, tok.CountTokens(prompt), shown
+		return opts.MaxTokens <= 0 || result.Tokens <= opts.MaxTokens, nil
 	}
 
-	lines = append(lines, "</available_skills>")
+	fits, err := render()
+	// Cut the skills of the lowest remaining priority, skills[start:end],
+	// until the prompt fits.
+	for end := len(skills); err == nil && !fits && end > 0; end = len(skills) {
+		start := end - 1
+		for start > 0 && priorities[start-1] == priorities[end-1] {
+			start--
+		}
+		// Shorten their descriptions, last first.
+		for i := end - 1; i >= start && err == nil && !fits; i-- {
+			short := shortenDescription(skills[i].Description, minDesc)
+			if short == skills[i].Description {
+				continue
+			}
+			skills[i].Description = short
+			result.Omissions = append(result.Omissions, PromptOmission{Name: skills[i].Name, Location: skills[i].Location})
+			fits, err = render()
+		}
+		// Then drop them, last first.
+		for err == nil && !fits && len(skills) > start {
+			last := skills[len(skills)-1]
+			skills = skills[:len(skills)-1]
+			result.Omissions = slices.DeleteFunc(result.Omissions, func(o PromptOmission) bool {
+				return o.Location == last.Location && o.Name == last.Name
+			})
+			result.Omissions = append(result.Omissions, PromptOmission{Name: last.Name, Location: last.Location, Dropped: true})
+			fits, err = render()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !fits {
+		return nil, fmt.Errorf("prompt needs %d tokens without any skills, more than the budget of %d", result.Tokens, opts.MaxTokens)
+	}
+	return result, nil
+}
 
-	return strings.Join(lines, "\n"), nil
+// shortenDescription cuts desc to at most limit characters at a word
+// boundary, marking the cut with an ellipsis.
+func shortenDescription(desc string, limit int) string {
+	runes := []rune(desc)
+	if len(runes) <= limit {
+		return desc
+	}
+	cut := string(runes[:limit-1])
+	if i := strings.LastIndexAny(cut, " \t\n"); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " \t\n.,;:") + "…"
 }
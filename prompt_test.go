@@ -1,10 +1,12 @@
 package agentskills
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestToPrompt_EmptyList(t *testing.T) {
@@ -118,3 +120,190 @@ func TestToPrompt_UsesAbsolutePath(t *testing.T) {
 		t.Errorf("expected absolute path %s in output, got: %s", expectedPath, result)
 	}
 }
+
+func TestToPromptFS_UsesFSPaths(t *testing.T) {
+	fsys := fstest.MapFS{
+		"skills/alpha/SKILL.md": {Data: []byte("---\nname: alpha\ndescription: First skill\n---\n")},
+		"skills/beta/skill.md":  {Data: []byte("---\nname: beta\ndescription: Second skill\n---\n")},
+	}
+
+	result, err := ToPromptFS(fsys, []string{"skills/alpha", "skills/beta"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "<location>\nskills/alpha/SKILL.md\n</location>") {
+		t.Errorf("expected fs.FS location for alpha, got: %s", result)
+	}
+	if !strings.Contains(result, "<location>\nskills/beta/skill.md\n</location>") {
+		t.Errorf("expected fs.FS location for beta, got: %s", result)
+	}
+}
+
+func TestToPromptFS_MissingSkill(t *testing.T) {
+	if _, err := ToPromptFS(fstest.MapFS{}, []string{"missing"}); err == nil {
+		t.Error("expected error for missing skill")
+	}
+}
+
+// budgetSkills returns n skills with long descriptions.
+func budgetSkills(n int) []PromptSkill {
+	skills := make([]PromptSkill, n)
+	for i := range skills {
+		name := fmt.Sprintf("skill-%d", i)
+		skills[i] = PromptSkill{
+			SkillProperties: SkillProperties{
+				Name:        name,
+				Description: strings.Repeat("Handles a specific kind of task when the user asks for it. ", 5),
+			},
+			Location: "/skills/" + name + "/SKILL.md",
+		}
+	}
+	return skills
+}
+
+func TestRenderPrompt_NoBudget(t *testing.T) {
+	skills := budgetSkills(3)
+	result, err := RenderPrompt(skills, PromptOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := XMLRenderer{}.Render(skills)
+	if result.Prompt != want || len(result.Omissions) != 0 || len(result.Skills) != 3 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.Tokens != (ApproxTokenizer{}).CountTokens(want) {
+		t.Errorf("Tokens = %d", result.Tokens)
+	}
+}
+
+func TestRenderPrompt_Budget(t *testing.T) {
+	skills := budgetSkills(4)
+	full, err := RenderPrompt(skills, PromptOptions{Renderer: TextRenderer{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Slightly too large: the lowest priority description is shortened.
+	opts := PromptOptions{
+		Renderer:  TextRenderer{},
+		MaxTokens: full.Tokens - 5,
+		Priority: func(s PromptSkill) int {
+			if s.Name == "skill-0" {
+				return -1
+			}
+			return 0
+		},
+	}
+	result, err := RenderPrompt(skills, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Tokens > opts.MaxTokens {
+		t.Errorf("Tokens = %d exceeds budget %d", result.Tokens, opts.MaxTokens)
+	}
+	if len(result.Omissions) != 1 || result.Omissions[0] != (PromptOmission{Name: "skill-0", Location: "/skills/skill-0/SKILL.md"}) {
+		t.Errorf("unexpected omissions: %+v", result.Omissions)
+	}
+	if got := result.Skills[3]; got.Name != "skill-0" || !strings.HasSuffix(got.Description, "…") || len([]rune(got.Description)) > DefaultMinDescription {
+		t.Errorf("expected skill-0 last with a short description, got %+v", got)
+	}
+	if skills[0].Description == result.Skills[3].Description {
+		t.Error("RenderPrompt modified its argument")
+	}
+
+	// Much too large: descriptions are shortened, then skills dropped.
+	opts.MaxTokens = full.Tokens / 4
+	result, err = RenderPrompt(skills, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Tokens > opts.MaxTokens || len(result.Skills) == 0 || len(result.Skills) == 4 {
+		t.Fatalf("unexpected result: %d tokens, %d skills", result.Tokens, len(result.Skills))
+	}
+	var dropped []string
+	for _, o := range result.Omissions {
+		if o.Dropped {
+			dropped = append(dropped, o.Name)
+		}
+	}
+	if len(dropped) != 4-len(result.Skills) || dropped[0] != "skill-0" {
+		t.Errorf("expected the lowest priority skill dropped first, got %+v", result.Omissions)
+	}
+	if len(result.Omissions) != 4 {
+		t.Errorf("expected one omission per cut skill, got %+v", result.Omissions)
+	}
+}
+
+func TestRenderPrompt_BudgetPriorityOrder(t *testing.T) {
+	skills := budgetSkills(4)
+	rest, err := RenderPrompt(skills[1:], PromptOptions{Renderer: TextRenderer{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Dropping the lowest priority skill fits the budget, so the others
+	// keep their full descriptions.
+	opts := PromptOptions{
+		Renderer:  TextRenderer{},
+		MaxTokens: rest.Tokens,
+		Priority: func(s PromptSkill) int {
+			if s.Name == "skill-0" {
+				return -1
+			}
+			return 0
+		},
+	}
+	result, err := RenderPrompt(skills, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Prompt != rest.Prompt {
+		t.Errorf("expected the other skills untouched, got:\n%s", result.Prompt)
+	}
+	if len(result.Omissions) != 1 || result.Omissions[0] != (PromptOmission{Name: "skill-0", Location: "/skills/skill-0/SKILL.md", Dropped: true}) {
+		t.Errorf("unexpected omissions: %+v", result.Omissions)
+	}
+}
+
+func TestRenderPrompt_BudgetTooSmall(t *testing.T) {
+	if _, err := RenderPrompt(budgetSkills(2), PromptOptions{MaxTokens: 1}); err == nil {
+		t.Error("expected an error when the prompt cannot fit the budget")
+	}
+}
+
+func TestRenderPrompt_Tokenizer(t *testing.T) {
+	words := TokenizerFunc(func(text string) int { return len(strings.Fields(text)) })
+	result, err := RenderPrompt(budgetSkills(2), PromptOptions{Renderer: TextRenderer{}, Tokenizer: words, MaxTokens: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Tokens > 60 || len(strings.Fields(result.Prompt)) != result.Tokens {
+		t.Errorf("unexpected result: %d tokens:\n%s", result.Tokens, result.Prompt)
+	}
+}
+
+func TestApproxTokenizer(t *testing.T) {
+	tests := map[string]int{
+		"":                  0,
+		"hello":             2,
+		"a b c":             3,
+		"<name>x</name>":    8,
+		"internationalized": 5,
+	}
+	for text, want := range tests {
+		if got := (ApproxTokenizer{}).CountTokens(text); got != want {
+			t.Errorf("CountTokens(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
+
+func TestGeneratePromptFS(t *testing.T) {
+	result, err := GeneratePromptFS(rendererFS, []string{"skills/alpha", "skills/beta"}, PromptOptions{Renderer: TextRenderer{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Skills) != 2 || !strings.HasPrefix(result.Prompt, "alpha: ") {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
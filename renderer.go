@@ -0,0 +1,387 @@
+package agentskills
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// PromptSkill is the information about one skill that a PromptRenderer
+// formats: its frontmatter properties and the location of its SKILL.md.
+type PromptSkill struct {
+	SkillProperties
+
+	// Location is the path of the skill's SKILL.md file.
+	Location string
+}
+
+// PromptRenderer formats skills for inclusion in an agent prompt. The
+// built-in renderers are XMLRenderer, MarkdownRenderer, JSONRenderer and
+// TextRenderer.
+type PromptRenderer interface {
+	Render(skills []PromptSkill) (string, error)
+}
+
+// PromptRendererFunc adapts a function to the PromptRenderer interface.
+type PromptRendererFunc func(skills []PromptSkill) (string, error)
+
+// Render calls f(skills).
+func (f PromptRendererFunc) Render(skills []PromptSkill) (string, error) {
+	return f(skills)
+}
+
+// PromptFields selects optional skill fields for a renderer to show after
+// the description, such as the compatibility a model should check before
+// using a skill. Empty fields are left out.
+type PromptFields struct {
+	License       bool
+	Compatibility bool
+	AllowedTools  bool
+
+	// Metadata lists the metadata keys to show, in order.
+	Metadata []string
+}
+
+// promptField is an optional field selected by PromptFields.
+type promptField struct {
+	name  string // frontmatter field name, or "metadata"
+	key   string // metadata key
+	value string
+}
+
+// fields returns the fields of s that f selects and s has.
+func (f PromptFields) fields(s PromptSkill) []promptField {
+	var fields []promptField
+	add := func(ok bool, name, value string) {
+		if ok && value != "" {
+			fields = append(fields, promptField{name: name, value: value})
+		}
+	}
+	add(f.License, "license", s.License)
+	add(f.Compatibility, "compatibility", s.Compatibility)
+	add(f.AllowedTools, "allowed-tools", s.AllowedTools)
+	for _, key := range f.Metadata {
+		if value := s.Metadata[key]; value != "" {
+			fields = append(fields, promptField{name: "metadata", key: key, value: value})
+		}
+	}
+	return fields
+}
+
+// label returns the name of the field, or its metadata key.
+func (f promptField) label() string {
+	if f.name == "metadata" {
+		return f.key
+	}
+	return f.name
+}
+
+// XMLLayout selects how XMLRenderer lays out the <available_skills> block.
+type XMLLayout int
+
+const (
+	// XMLLegacy puts every tag and value on a line of its own, as the
+	// reference skills-ref implementation does:
+	//
+	//	<available_skills>
+	//	<skill>
+	//	<name>
+	//	pdf-reader
+	//	</name>
+	//	...
+	XMLLegacy XMLLayout = iota
+
+	// XMLPretty indents nested elements by two spaces and keeps values
+	// inline:
+	//
+	//	<available_skills>
+	//	  <skill>
+	//	    <name>pdf-reader</name>
+	//	    ...
+	XMLPretty
+
+	// XMLCompact writes the whole block on one line, with no whitespace
+	// between elements, to save tokens.
+	XMLCompact
+)
+
+// XMLRenderer renders the <available_skills> block produced by ToPrompt.
+// Names, descriptions and locations are escaped as XML character data, and
+// characters that XML does not allow are replaced with U+FFFD. The
+// <location> element is left out when the location is empty.
+//
+// Fields adds elements named after the frontmatter fields between the
+// description and the location, with metadata as
+// <metadata key="version">1.0</metadata>.
+type XMLRenderer struct {
+	// Layout is the layout of the block. The zero value is XMLLegacy,
+	// which ToPrompt uses.
+	Layout XMLLayout
+
+	Fields PromptFields
+}
+
+// Render implements PromptRenderer.
+func (r XMLRenderer) Render(skills []PromptSkill) (string, error) {
+	w := &xmlWriter{layout: r.Layout}
+	w.open("available_skills")
+	for _, s := range skills {
+		w.open("skill")
+		w.element("name", "", s.Name)
+		w.element("description", "", s.Description)
+		for _, f := range r.Fields.fields(s) {
+			w.element(f.name, f.key, f.value)
+		}
+		if s.Location != "" {
+			w.element("location", "", s.Location)
+		}
+		w.close("skill")
+	}
+	w.close("available_skills")
+	return w.sb.String(), nil
+}
+
+// xmlWriter writes XML elements in one of the XMLLayouts.
+type xmlWriter struct {
+	sb     strings.Builder
+	layout XMLLayout
+	depth  int
+}
+
+// newline starts a line for the next tag or value, unless the layout is
+// compact.
+func (w *xmlWriter) newline() {
+	if w.layout == XMLCompact {
+		return
+	}
+	if w.sb.Len() > 0 {
+		w.sb.WriteByte('\n')
+	}
+	if w.layout == XMLPretty {
+		w.sb.WriteString(strings.Repeat("  ", w.depth))
+	}
+}
+
+func (w *xmlWriter) open(tag string) {
+	w.newline()
+	w.sb.WriteString("<" + tag + ">")
+	w.depth++
+}
+
+func (w *xmlWriter) close(tag string) {
+	w.depth--
+	w.newline()
+	w.sb.WriteString("</" + tag + ">")
+}
+
+// element writes an element holding text, with a key attribute unless key
+// is empty.
+func (w *xmlWriter) element(tag, key, text string) {
+	w.newline()
+	w.sb.WriteString("<" + tag)
+	if key != "" {
+		w.sb.WriteString(` key="`)
+		writeXMLText(&w.sb, key, true)
+		w.sb.WriteByte('"')
+	}
+	w.sb.WriteByte('>')
+	if w.layout == XMLLegacy {
+		w.sb.WriteByte('\n')
+		writeXMLText(&w.sb, text, false)
+		w.sb.WriteByte('\n')
+	} else {
+		writeXMLText(&w.sb, text, false)
+	}
+	w.sb.WriteString("</" + tag + ">")
+}
+
+// writeXMLText writes s as XML character data, or as a double-quoted
+// attribute value if attr is set. Line breaks in character data are kept,
+// so that multi-line descriptions stay readable.
+func writeXMLText(sb *strings.Builder, s string, attr bool) {
+	for _, r := range s {
+		switch {
+		case attr && r == '"':
+			sb.WriteString("&quot;")
+		case attr && (r == '\t' || r == '\n' || r == '\r'):
+			fmt.Fprintf(sb, "&#x%X;", r)
+		case r == '&':
+			sb.WriteString("&amp;")
+		case r == '<':
+			sb.WriteString("&lt;")
+		case r == '>':
+			sb.WriteString("&gt;")
+		case !isXMLChar(r):
+			sb.WriteRune(utf8.RuneError)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+}
+
+// isXMLChar reports whether r is in the Char production of the XML 1.0
+// specification. Invalid UTF-8 decodes to utf8.RuneError, which is.
+func isXMLChar(r rune) bool {
+	return r == '\t' || r == '\n' || r == '\r' ||
+		r >= 0x20 && r <= 0xD7FF ||
+		r >= 0xE000 && r <= 0xFFFD ||
+		r >= 0x10000 && r <= 0x10FFFF
+}
+
+// MarkdownRenderer renders skills as a Markdown bullet list under an
+// "Available skills" heading:
+//
+//	## Available skills
+//
+//	- **pdf-reader**: Read and extract text from PDF files
+//	  Location: `/path/to/pdf-reader/SKILL.md`
+//
+// It renders nothing when there are no skills. Skills without a location
+// have no Location line. Fields adds a line for each field, such as
+// "Compatibility: Requires Python 3.11+", before the location.
+type MarkdownRenderer struct {
+	Fields PromptFields
+}
+
+// Render implements PromptRenderer.
+func (r MarkdownRenderer) Render(skills []PromptSkill) (string, error) {
+	if len(skills) == 0 {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Available skills\n")
+	for _, s := range skills {
+		sb.WriteString("\n- **")
+		sb.WriteString(markdownEscaper.Replace(s.Name))
+		sb.WriteString("**: ")
+		sb.WriteString(markdownEscaper.Replace(singleLine(s.Description)))
+		for _, f := range r.Fields.fields(s) {
+			sb.WriteString("\n  ")
+			switch f.name {
+			case "metadata":
+				sb.WriteString(markdownEscaper.Replace(f.key) + ": " + markdownEscaper.Replace(singleLine(f.value)))
+			case "allowed-tools":
+				sb.WriteString("Allowed tools: " + markdownCode(singleLine(f.value)))
+			default:
+				sb.WriteString(strings.ToUpper(f.name[:1]) + f.name[1:] + ": " + markdownEscaper.Replace(singleLine(f.value)))
+			}
+		}
+		if s.Location != "" {
+			sb.WriteString("\n  Location: ")
+			sb.WriteString(markdownCode(s.Location))
+		}
+	}
+	return sb.String(), nil
+}
+
+// JSONRenderer renders skills as an indented JSON array of objects with
+// name, description and location members. The location member is left
+// out when it is empty. Fields adds members named after the frontmatter
+// fields, with the selected metadata as a "metadata" object.
+type JSONRenderer struct {
+	Fields PromptFields
+}
+
+// Render implements PromptRenderer.
+func (r JSONRenderer) Render(skills []PromptSkill) (string, error) {
+	type entry struct {
+		Name          string            `json:"name"`
+		Description   string            `json:"description"`
+		License       string            `json:"license,omitempty"`
+		Compatibility string            `json:"compatibility,omitempty"`
+		AllowedTools  string            `json:"allowed-tools,omitempty"`
+		Metadata      map[string]string `json:"metadata,omitempty"`
+		Location      string            `json:"location,omitempty"`
+	}
+	entries := make([]entry, 0, len(skills))
+	for _, s := range skills {
+		e := entry{Name: s.Name, Description: s.Description, Location: s.Location}
+		for _, f := range r.Fields.fields(s) {
+			switch f.name {
+			case "license":
+				e.License = f.value
+			case "compatibility":
+				e.Compatibility = f.value
+			case "allowed-tools":
+				e.AllowedTools = f.value
+			case "metadata":
+				if e.Metadata == nil {
+					e.Metadata = make(map[string]string)
+				}
+				e.Metadata[f.key] = f.value
+			}
+		}
+		entries = append(entries, e)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(entries); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// TextRenderer renders one compact line per skill, for models or budgets
+// where markup is unwanted:
+//
+//	pdf-reader: Read and extract text from PDF files [/path/to/pdf-reader/SKILL.md]
+//
+// It renders nothing when there are no skills. The bracketed location is
+// left out when it is empty. Fields adds the selected fields in
+// parentheses before the location:
+//
+//	pdf-reader: Read PDF files (compatibility: Requires poppler) [/path/...]
+type TextRenderer struct {
+	Fields PromptFields
+}
+
+// Render implements PromptRenderer.
+func (r TextRenderer) Render(skills []PromptSkill) (string, error) {
+	lines := make([]string, 0, len(skills))
+	for _, s := range skills {
+		line := s.Name + ": " + singleLine(s.Description)
+		if fields := r.Fields.fields(s); len(fields) > 0 {
+			parts := make([]string, len(fields))
+			for i, f := range fields {
+				parts[i] = f.label() + ": " + singleLine(f.value)
+			}
+			line += " (" + strings.Join(parts, "; ") + ")"
+		}
+		if s.Location != "" {
+			line += " [" + s.Location + "]"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// markdownEscaper escapes the characters that start Markdown emphasis,
+// code, links or HTML within inline text.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`,
+)
+
+// markdownCode returns s as a Markdown code span, using a backtick fence
+// longer than any run of backticks in s.
+func markdownCode(s string) string {
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return fence + s + fence
+}
+
+// singleLine joins the lines of s with spaces, so that a multi-line
+// description stays within one list item or line.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
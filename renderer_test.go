@@ -0,0 +1,237 @@
+package agentskills
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// rendererFS holds two skills whose descriptions need escaping.
+var rendererFS = fstest.MapFS{
+	"skills/alpha/SKILL.md": {Data: []byte("---\nname: alpha\ndescription: Use <foo> & *bar*\n---\n")},
+	"skills/beta/SKILL.md":  {Data: []byte("---\nname: beta\ndescription: |\n  Two\n  lines\n---\n")},
+}
+
+func TestToPromptWith_Renderers(t *testing.T) {
+	tests := []struct {
+		name     string
+		renderer PromptRenderer
+		want     string
+	}{
+		{"xml", XMLRenderer{}, "<available_skills>\n<skill>\n<name>\nalpha\n</name>\n<description>\nUse &lt;foo&gt; &amp; *bar*\n</description>\n<location>\nskills/alpha/SKILL.md\n</location>\n</skill>\n" +
+			"<skill>\n<name>\nbeta\n</name>\n<description>\nTwo\nlines\n</description>\n<location>\nskills/beta/SKILL.md\n</location>\n</skill>\n</available_skills>"},
+		{"markdown", MarkdownRenderer{}, "## Available skills\n\n- **alpha**: Use \\<foo\\> & \\*bar\\*\n  Location: `skills/alpha/SKILL.md`\n" +
+			"- **beta**: Two lines\n  Location: `skills/beta/SKILL.md`"},
+		{"text", TextRenderer{}, "alpha: Use <foo> & *bar* [skills/alpha/SKILL.md]\nbeta: Two lines [skills/beta/SKILL.md]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToPromptFSWith(rendererFS, []string{"skills/alpha", "skills/beta"}, tt.renderer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONRenderer(t *testing.T) {
+	got, err := ToPromptFSWith(rendererFS, []string{"skills/alpha", "skills/beta"}, JSONRenderer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, `"description": "Use <foo> & *bar*"`) {
+		t.Errorf("expected unescaped HTML characters, got:\n%s", got)
+	}
+
+	var entries []map[string]string
+	if err := json.Unmarshal([]byte(got), &entries); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[1]["name"] != "beta" || entries[1]["description"] != "Two\nlines" || entries[1]["location"] != "skills/beta/SKILL.md" {
+		t.Errorf("unexpected entries: %v", entries)
+	}
+
+	if empty, _ := (JSONRenderer{}).Render(nil); empty != "[]" {
+		t.Errorf("empty list rendered as %q", empty)
+	}
+}
+
+func TestToPromptWith_CustomRenderer(t *testing.T) {
+	r := PromptRendererFunc(func(skills []PromptSkill) (string, error) {
+		names := make([]string, len(skills))
+		for i, s := range skills {
+			names[i] = s.Name + "@" + s.License
+		}
+		return strings.Join(names, ","), nil
+	})
+	got, err := ToPromptWith([]string{"testdata/valid-skill", "testdata/valid-all-fields"}, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "valid-skill@,valid-all-fields@MIT" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestMarkdownCode(t *testing.T) {
+	tests := map[string]string{
+		"plain":   "`plain`",
+		"a`b":     "``a`b``",
+		"`start":  "`` `start ``",
+		"a``b`":   "``` a``b` ```",
+		"no-tick": "`no-tick`",
+	}
+	for in, want := range tests {
+		if got := markdownCode(in); got != want {
+			t.Errorf("markdownCode(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// goldenSkills need every kind of escaping.
+var goldenSkills = []PromptSkill{
+	{SkillProperties: SkillProperties{Name: "r-and-d", Description: `Use <tag attr="x"> & 'quotes' ]]>`}, Location: "/skills/R&D <new>/SKILL.md"},
+	{SkillProperties: SkillProperties{Name: "multi-line", Description: "First line\nsecond line\x01\ufffe"}, Location: "/skills/multi-line/SKILL.md"},
+	{SkillProperties: SkillProperties{Name: "no-location", Description: "Found by name"}},
+}
+
+func TestXMLRenderer_Golden(t *testing.T) {
+	layouts := map[string]XMLLayout{"legacy": XMLLegacy, "pretty": XMLPretty, "compact": XMLCompact}
+	for name, layout := range layouts {
+		t.Run(name, func(t *testing.T) {
+			for _, tt := range []struct {
+				file   string
+				skills []PromptSkill
+			}{
+				{name + ".xml", goldenSkills},
+				{name + "-empty.xml", nil},
+			} {
+				got, err := XMLRenderer{Layout: layout}.Render(tt.skills)
+				if err != nil {
+					t.Fatal(err)
+				}
+				golden := filepath.Join("testdata", "prompt", tt.file)
+				if *update {
+					if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+						t.Fatal(err)
+					}
+				}
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != string(want) {
+					t.Errorf("%s: got:\n%s\nwant:\n%s", tt.file, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestXMLRenderer_WellFormed(t *testing.T) {
+	for _, layout := range []XMLLayout{XMLLegacy, XMLPretty, XMLCompact} {
+		got, err := XMLRenderer{Layout: layout}.Render(goldenSkills)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var block struct {
+			Skills []struct {
+				Name        string `xml:"name"`
+				Description string `xml:"description"`
+				Location    string `xml:"location"`
+			} `xml:"skill"`
+		}
+		if err := xml.Unmarshal([]byte(got), &block); err != nil {
+			t.Fatalf("layout %d: %v\n%s", layout, err, got)
+		}
+		if len(block.Skills) != 3 {
+			t.Errorf("layout %d: expected 3 skills, got %+v", layout, block.Skills)
+			continue
+		}
+		first := block.Skills[0]
+		if layout == XMLLegacy {
+			first.Location = strings.TrimSpace(first.Location)
+		}
+		if first.Location != goldenSkills[0].Location {
+			t.Errorf("layout %d: location did not round-trip: %+v", layout, block.Skills)
+		}
+	}
+}
+
+func TestRenderers_Fields(t *testing.T) {
+	fields := PromptFields{License: true, Compatibility: true, AllowedTools: true, Metadata: []string{"version", "missing", "author"}}
+	skill := PromptSkill{
+		SkillProperties: SkillProperties{
+			Name:          "tools",
+			Description:   "Run git",
+			Compatibility: "Requires Python 3.11+",
+			AllowedTools:  "Bash(git:*)",
+			Metadata:      map[string]string{"author": `A "B" <c>`, "version": "1.0"},
+		},
+		Location: "/skills/tools/SKILL.md",
+	}
+	tests := []struct {
+		name     string
+		renderer PromptRenderer
+		want     string
+	}{
+		{"xml", XMLRenderer{Layout: XMLPretty, Fields: fields}, `<available_skills>
+  <skill>
+    <name>tools</name>
+    <description>Run git</description>
+    <compatibility>Requires Python 3.11+</compatibility>
+    <allowed-tools>Bash(git:*)</allowed-tools>
+    <metadata key="version">1.0</metadata>
+    <metadata key="author">A "B" &lt;c&gt;</metadata>
+    <location>/skills/tools/SKILL.md</location>
+  </skill>
+</available_skills>`},
+		{"markdown", MarkdownRenderer{Fields: fields}, "## Available skills\n\n- **tools**: Run git\n  Compatibility: Requires Python 3.11+\n  Allowed tools: `Bash(git:*)`\n" +
+			"  version: 1.0\n  author: A \"B\" \\<c\\>\n  Location: `/skills/tools/SKILL.md`"},
+		{"json", JSONRenderer{Fields: fields}, `[
+  {
+    "name": "tools",
+    "description": "Run git",
+    "compatibility": "Requires Python 3.11+",
+    "allowed-tools": "Bash(git:*)",
+    "metadata": {
+      "author": "A \"B\" <c>",
+      "version": "1.0"
+    },
+    "location": "/skills/tools/SKILL.md"
+  }
+]`},
+		{"text", TextRenderer{Fields: fields}, `tools: Run git (compatibility: Requires Python 3.11+; allowed-tools: Bash(git:*); version: 1.0; author: A "B" <c>) [/skills/tools/SKILL.md]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.renderer.Render([]PromptSkill{skill})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+
+	got, err := XMLRenderer{Layout: XMLCompact, Fields: PromptFields{Metadata: []string{"a\"\n<b>"}}}.Render([]PromptSkill{{
+		SkillProperties: SkillProperties{Name: "x", Description: "y", Metadata: map[string]string{"a\"\n<b>": "v"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `<available_skills><skill><name>x</name><description>y</description><metadata key="a&quot;&#xA;&lt;b&gt;">v</metadata></skill></available_skills>`; got != want {
+		t.Errorf("metadata key attribute:\ngot  %s\nwant %s", got, want)
+	}
+}
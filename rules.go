@@ -0,0 +1,240 @@
+package agentskills
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// builtinChecks implements the built-in rules, keyed by code. Codes of
+// structural checks performed before any rule runs have no entry.
+var builtinChecks = map[Code]func(ctx *RuleContext){
+	CodeNameTooLong:            checkNameTooLong,
+	CodeNameNotLowercase:       checkNameNotLowercase,
+	CodeNameHyphenBoundary:     checkNameHyphenBoundary,
+	CodeNameConsecutiveHyphens: checkNameConsecutiveHyphens,
+	CodeNameInvalidCharacters:  checkNameInvalidCharacters,
+	CodeNameDirectoryMismatch:  checkNameDirectoryMismatch,
+	CodeNameMissing:            checkNameMissing,
+	CodeNameEmpty:              checkNameEmpty,
+	CodeDescriptionMissing:     checkDescriptionMissing,
+	CodeDescriptionEmpty:       checkDescriptionEmpty,
+	CodeDescriptionTooLong:     checkDescriptionTooLong,
+	CodeDescriptionTooShort:    checkDescriptionTooShort,
+	CodeCompatibilityTooLong:   checkCompatibilityTooLong,
+	CodeCompatibilityInvalid:   checkCompatibilityInvalid,
+	CodeUnexpectedField:        checkUnexpectedFields,
+	CodeSkillMDLowercase:       checkSkillMDLowercase,
+	CodeLicenseMissing:         checkLicenseMissing,
+	CodeBodyEmpty:              checkBodyEmpty,
+	CodeAllowedToolsInvalid:    checkAllowedToolsInvalid,
+}
+
+func checkNameTooLong(ctx *RuleContext) {
+	name := ctx.skillName()
+	limit := ctx.IntOption("max", MaxSkillNameLength)
+	if len(name) > limit {
+		ctx.Report("name", detailf(ErrNameTooLong, "skill name '%s' exceeds %d character limit (%d chars)",
+			name, limit, len(name)))
+	}
+}
+
+func checkNameNotLowercase(ctx *RuleContext) {
+	if name := ctx.skillName(); name != strings.ToLower(name) {
+		ctx.ReportFix("name", detailf(ErrNameNotLowercase, "skill name '%s' must be lowercase", name), nameFix(name))
+	}
+}
+
+func checkNameHyphenBoundary(ctx *RuleContext) {
+	if name := ctx.skillName(); strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-") {
+		ctx.ReportFix("name", ErrNameLeadingHyphen, nameFix(name))
+	}
+}
+
+func checkNameConsecutiveHyphens(ctx *RuleContext) {
+	if name := ctx.skillName(); strings.Contains(name, "--") {
+		ctx.ReportFix("name", ErrNameConsecutiveHyphen, nameFix(name))
+	}
+}
+
+func checkNameInvalidCharacters(ctx *RuleContext) {
+	name := ctx.skillName()
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' {
+			ctx.Report("name", detailf(ErrNameInvalidChars, "skill name '%s' contains invalid characters. Only letters, digits, and hyphens are allowed", name))
+			return
+		}
+	}
+}
+
+func checkNameDirectoryMismatch(ctx *RuleContext) {
+	name := ctx.skillName()
+	if ctx.Dir == "" || name == "" {
+		return
+	}
+	dirName := norm.NFKC.String(filepath.Base(ctx.Dir))
+	if dirName == name {
+		return
+	}
+
+	// Change the name to the directory name, unless the rule's
+	// "rename-directory" option asks to rename the directory after the
+	// name instead.
+	var fix *Fix
+	renameDir, _ := ctx.Option("rename-directory")
+	if fixed := fixedName(name); fixed == dirName {
+		fix = nameFix(name)
+	} else if renameDir == true && isValidName(fixed) {
+		fix = &Fix{Description: fmt.Sprintf("rename directory to '%s'", fixed), RenameDir: fixed}
+	} else if isValidName(dirName) {
+		fix = setFieldFix("name", dirName)
+	}
+	ctx.ReportFix("name", detailf(ErrNameDirectoryMismatch, "directory name '%s' must match skill name '%s'",
+		filepath.Base(ctx.Dir), name), fix)
+}
+
+func checkNameMissing(ctx *RuleContext) {
+	if _, ok := ctx.Metadata["name"]; !ok {
+		ctx.ReportAt(ctx.KeyPos("name"), "name", ErrMissingName)
+	}
+}
+
+func checkNameEmpty(ctx *RuleContext) {
+	if _, ok := ctx.Metadata["name"]; ok && ctx.skillName() == "" {
+		ctx.Report("name", ErrNameEmpty)
+	}
+}
+
+func checkDescriptionMissing(ctx *RuleContext) {
+	if _, ok := ctx.Metadata["description"]; !ok {
+		ctx.ReportAt(ctx.KeyPos("description"), "description", ErrMissingDescription)
+	}
+}
+
+func checkDescriptionEmpty(ctx *RuleContext) {
+	if _, ok := ctx.Metadata["description"]; ok && ctx.Properties.Description == "" {
+		ctx.Report("description", ErrDescriptionEmpty)
+	}
+}
+
+func checkDescriptionTooLong(ctx *RuleContext) {
+	desc, _ := ctx.Metadata["description"].(string)
+	limit := ctx.IntOption("max", MaxDescriptionLength)
+	if len(desc) > limit {
+		ctx.Report("description", detailf(ErrDescriptionTooLong, "description exceeds %d character limit (%d chars)",
+			limit, len(desc)))
+	}
+}
+
+func checkDescriptionTooShort(ctx *RuleContext) {
+	n := len(ctx.Properties.Description)
+	if n > 0 && n < ctx.IntOption("min", MinDescriptionLength) {
+		ctx.Report("description", detailf(ErrDescriptionTooShort, "description is only %d chars; describe what the skill does and when to use it",
+			n))
+	}
+}
+
+func checkCompatibilityTooLong(ctx *RuleContext) {
+	compat, _ := ctx.Metadata["compatibility"].(string)
+	limit := ctx.IntOption("max", MaxCompatibilityLength)
+	if len(compat) > limit {
+		ctx.Report("compatibility", detailf(ErrCompatibilityTooLong, "compatibility exceeds %d character limit (%d chars)",
+			limit, len(compat)))
+	}
+}
+
+// checkCompatibilityInvalid reports a compatibility field that starts like
+// structured compatibility but does not follow its grammar, since
+// environment filtering would otherwise ignore it.
+func checkCompatibilityInvalid(ctx *RuleContext) {
+	compat, _ := ctx.Metadata["compatibility"].(string)
+	if _, err := ParseCompatibility(compat); errors.Is(err, ErrCompatibilityInvalid) {
+		ctx.Report("compatibility", err)
+	}
+}
+
+// checkUnexpectedFields reports one finding per field outside allowedFields
+// and the "allowed" option, in alphabetical order.
+func checkUnexpectedFields(ctx *RuleContext) {
+	allowed := make(map[string]bool, len(allowedFields))
+	for k := range allowedFields {
+		allowed[k] = true
+	}
+	for _, k := range ctx.StringsOption("allowed") {
+		allowed[k] = true
+	}
+
+	var extraFields []string
+	for key := range ctx.Metadata {
+		if !allowed[key] {
+			extraFields = append(extraFields, key)
+		}
+	}
+	if len(extraFields) == 0 {
+		return
+	}
+
+	sort.Strings(extraFields)
+	names := make([]string, 0, len(allowed))
+	for k := range allowed {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	meta, hasMeta := ctx.Metadata["metadata"]
+	metaMap, _ := meta.(map[string]string)
+	for _, field := range extraFields {
+		ctx.ReportAt(ctx.KeyPos(field), field, detailf(ErrUnexpectedField, "unexpected field in frontmatter: %s. Only %v are allowed",
+			field, names))
+
+		// A scalar field can move under metadata unless it would replace
+		// a different value there.
+		switch value := ctx.Metadata[field].(type) {
+		case string, bool, int, int64, uint64, float64:
+			if existing, ok := metaMap[field]; (!hasMeta || metaMap != nil) && (!ok || existing == fmt.Sprint(value)) {
+				ctx.attachFix(moveToMetadataFix(field))
+			}
+		}
+	}
+}
+
+func checkSkillMDLowercase(ctx *RuleContext) {
+	if ctx.Path == "" {
+		return
+	}
+	if name := filepath.Base(ctx.Path); name == "skill.md" {
+		ctx.ReportFix("", detailf(ErrSkillMDLowercase, "skill file should be named SKILL.md, not %s", name),
+			&Fix{Description: "rename skill.md to SKILL.md", RenameFile: "SKILL.md"})
+	}
+}
+
+func checkLicenseMissing(ctx *RuleContext) {
+	if _, ok := ctx.Metadata["license"]; !ok {
+		ctx.ReportAt(ctx.KeyPos("license"), "license", ErrLicenseMissing)
+	}
+}
+
+func checkBodyEmpty(ctx *RuleContext) {
+	if ctx.Body == "" && ctx.src != nil {
+		ctx.Report("", ErrBodyEmpty)
+	}
+}
+
+// checkAllowedToolsInvalid reports an allowed-tools field that
+// ParseAllowedTools rejects, with a fix when the tools are separated by
+// commas instead of spaces.
+func checkAllowedToolsInvalid(ctx *RuleContext) {
+	tools, _ := ctx.Metadata["allowed-tools"].(string)
+	if _, err := ParseAllowedTools(tools); err != nil {
+		var fix *Fix
+		if ps, err := ParseAllowedTools(strings.ReplaceAll(tools, ",", " ")); err == nil && len(ps) > 0 {
+			fix = setFieldFix("allowed-tools", ps.String())
+		}
+		ctx.ReportFix("allowed-tools", err, fix)
+	}
+}
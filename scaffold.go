@@ -0,0 +1,219 @@
+package agentskills
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// ScaffoldOptions controls Scaffold.
+type ScaffoldOptions struct {
+	// Description is the skill description. If empty, a placeholder
+	// asking the author to describe the skill is used.
+	Description string
+
+	// Optional frontmatter fields, written when non-empty.
+	License       string
+	Compatibility string
+	AllowedTools  string
+	Metadata      map[string]string
+
+	// Scripts, References and Assets create the optional scripts/,
+	// references/ and assets/ directories from the built-in templates.
+	Scripts    bool
+	References bool
+	Assets     bool
+
+	// Template, if set, is a directory tree copied into the new skill.
+	// Files ending in ".tmpl" are executed with text/template and a
+	// ScaffoldData, and written without the suffix. A SKILL.md or
+	// SKILL.md.tmpl file at its root replaces the built-in markdown body;
+	// the frontmatter is always generated from the options.
+	Template fs.FS
+}
+
+// ScaffoldData is the data passed to scaffold templates.
+type ScaffoldData struct {
+	SkillProperties
+
+	// Title is the skill name in title case, such as "Pdf Reader".
+	Title string
+}
+
+// builtinBody is the markdown body of a new skill.
+const builtinBody = `# {{.Title}}
+
+## When to use
+
+TODO: Describe the situations in which an agent should use this skill.
+
+## Instructions
+
+TODO: Describe step by step how to perform the task.
+`
+
+// builtinResources are the files created for the optional directories.
+var builtinResources = map[string]string{
+	"scripts/README.md":    "# Scripts\n\nExecutable code the agent can run for {{.Name}}.\n",
+	"references/README.md": "# References\n\nDocumentation the agent can read when it needs more detail.\n",
+	"assets/README.md":     "# Assets\n\nTemplates, images and other files used in the output.\n",
+}
+
+// Scaffold creates a new skill named name in parentDir and returns its
+// directory. The directory must not exist yet. The generated SKILL.md
+// passes Validate, including the rules of the nearest .agentskills.yaml
+// file; if name or the options would produce an invalid skill, Scaffold
+// returns the *ValidationErrors and creates nothing.
+func Scaffold(parentDir, name string, opts ScaffoldOptions) (string, error) {
+	skillDir := filepath.Join(parentDir, name)
+
+	props := &SkillProperties{
+		Name:          name,
+		Description:   opts.Description,
+		License:       opts.License,
+		Compatibility: opts.Compatibility,
+		AllowedTools:  opts.AllowedTools,
+		Metadata:      opts.Metadata,
+	}
+	if props.Description == "" {
+		props.Description = fmt.Sprintf("TODO: Describe what %s does and when to use it.", name)
+	}
+
+	v := NewValidator()
+	v.DiscoverConfig = true
+	v, err := v.forSkill(osSkillFS(skillDir))
+	if err != nil {
+		return "", err
+	}
+	errs := v.CheckMetadata(props.ToMap(), skillDir)
+	failing := errs.BySeverity(SeverityError)
+	if v.Strict {
+		failing = append(failing, errs.BySeverity(SeverityWarning)...)
+	}
+	if err := (&ValidationErrors{Errors: failing}).AsError(); err != nil {
+		return "", err
+	}
+
+	files, err := scaffoldFiles(props, opts)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(skillDir), 0o755); err != nil {
+		return "", err
+	}
+	// Mkdir fails if the directory exists, even if it was created since
+	// the checks above.
+	if err := os.Mkdir(skillDir, 0o755); err != nil {
+		return "", err
+	}
+	for rel, content := range files {
+		if err := writeScaffoldFile(skillDir, rel, content); err != nil {
+			return "", errors.Join(err, os.RemoveAll(skillDir))
+		}
+	}
+	return skillDir, nil
+}
+
+// scaffoldFiles renders the files of a new skill, keyed by slash-separated
+// path relative to the skill directory.
+func scaffoldFiles(props *SkillProperties, opts ScaffoldOptions) (map[string][]byte, error) {
+	data := ScaffoldData{SkillProperties: *props, Title: titleCase(props.Name)}
+	files := make(map[string][]byte)
+
+	render := func(name, text string) ([]byte, error) {
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	body, err := render("SKILL.md", builtinBody)
+	if err != nil {
+		return nil, err
+	}
+
+	for rel, text := range builtinResources {
+		dir, _, _ := strings.Cut(rel, "/")
+		if (dir == "scripts" && !opts.Scripts) || (dir == "references" && !opts.References) || (dir == "assets" && !opts.Assets) {
+			continue
+		}
+		if files[rel], err = render(rel, text); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Template != nil {
+		err := fs.WalkDir(opts.Template, ".", func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			content, err := fs.ReadFile(opts.Template, p)
+			if err != nil {
+				return err
+			}
+			if rel, ok := strings.CutSuffix(p, ".tmpl"); ok {
+				if content, err = render(p, string(content)); err != nil {
+					return err
+				}
+				p = rel
+			}
+			if p == "SKILL.md" {
+				body = content
+				return nil
+			}
+			files[p] = content
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scaffold template: %w", err)
+		}
+	}
+
+	var skillMD bytes.Buffer
+	if err := Write(&skillMD, props, string(body)); err != nil {
+		return nil, err
+	}
+	files["SKILL.md"] = skillMD.Bytes()
+	return files, nil
+}
+
+// writeScaffoldFile writes content to the slash-separated path rel inside
+// skillDir, creating parent directories.
+func writeScaffoldFile(skillDir, rel string, content []byte) error {
+	if !fs.ValidPath(rel) {
+		return errors.New("scaffold template: invalid path " + rel)
+	}
+	p := filepath.Join(skillDir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	mode := os.FileMode(0o644)
+	if path.Dir(rel) == "scripts" && strings.HasSuffix(rel, ".sh") {
+		mode = 0o755
+	}
+	return os.WriteFile(p, content, mode)
+}
+
+// titleCase turns a kebab-case name into space-separated capitalized words.
+func titleCase(name string) string {
+	words := strings.Split(name, "-")
+	for i, w := range words {
+		if w != "" {
+			r := []rune(w)
+			words[i] = strings.ToUpper(string(r[0])) + string(r[1:])
+		}
+	}
+	return strings.Join(words, " ")
+}
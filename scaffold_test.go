@@ -0,0 +1,161 @@
+package agentskills
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestScaffold_Minimal(t *testing.T) {
+	parent := t.TempDir()
+	dir, err := Scaffold(parent, "pdf-reader", ScaffoldOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir != filepath.Join(parent, "pdf-reader") {
+		t.Errorf("unexpected directory %q", dir)
+	}
+	if err := Validate(dir); err != nil {
+		t.Errorf("scaffolded skill does not validate: %v", err)
+	}
+
+	skill, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(skill.Body, "# Pdf Reader") {
+		t.Errorf("unexpected body:\n%s", skill.Body)
+	}
+	if len(skill.Resources) != 0 {
+		t.Errorf("expected no resources, got %v", skill.Resources)
+	}
+}
+
+func TestScaffold_AllOptions(t *testing.T) {
+	parent := t.TempDir()
+	dir, err := Scaffold(parent, "release-notes", ScaffoldOptions{
+		Description:   "Drafts release notes: use when tagging a new version.",
+		License:       "Apache-2.0",
+		Compatibility: "Requires git",
+		AllowedTools:  "Bash(git:*)",
+		Metadata:      map[string]string{"owner": "docs", "version": "1.0"},
+		Scripts:       true,
+		References:    true,
+		Assets:        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := Check(dir, ValidateOptions{Strict: true})
+	if len(result.Errors) != 0 {
+		t.Errorf("expected no findings, got: %v", result)
+	}
+
+	skill, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &SkillProperties{
+		Name:          "release-notes",
+		Description:   "Drafts release notes: use when tagging a new version.",
+		License:       "Apache-2.0",
+		Compatibility: "Requires git",
+		AllowedTools:  "Bash(git:*)",
+		Metadata:      map[string]string{"owner": "docs", "version": "1.0"},
+	}
+	got := skill.Properties
+	if got.Name != want.Name || got.Description != want.Description || got.License != want.License ||
+		got.Compatibility != want.Compatibility || got.AllowedTools != want.AllowedTools ||
+		got.Metadata["owner"] != "docs" || got.Metadata["version"] != "1.0" {
+		t.Errorf("properties = %+v, want %+v", got, want)
+	}
+	wantResources := []string{"assets/README.md", "references/README.md", "scripts/README.md"}
+	if !equalStrings(skill.Resources, wantResources) {
+		t.Errorf("resources = %v, want %v", skill.Resources, wantResources)
+	}
+}
+
+func TestScaffold_Template(t *testing.T) {
+	tmpl := fstest.MapFS{
+		"SKILL.md.tmpl":       {Data: []byte("# {{.Title}}\n\nRun scripts/run.sh for {{.Name}}.\n")},
+		"scripts/run.sh.tmpl": {Data: []byte("#!/bin/sh\necho {{.Name}}\n")},
+		"assets/logo.txt":     {Data: []byte("{{not a template}}\n")},
+	}
+	parent := t.TempDir()
+	dir, err := Scaffold(parent, "my-tool", ScaffoldOptions{Template: tmpl})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(dir); err != nil {
+		t.Errorf("scaffolded skill does not validate: %v", err)
+	}
+
+	skill, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skill.Body != "# My Tool\n\nRun scripts/run.sh for my-tool." {
+		t.Errorf("unexpected body %q", skill.Body)
+	}
+	script, err := os.ReadFile(filepath.Join(dir, "scripts", "run.sh"))
+	if err != nil || string(script) != "#!/bin/sh\necho my-tool\n" {
+		t.Errorf("unexpected script %q (%v)", script, err)
+	}
+	logo, err := os.ReadFile(filepath.Join(dir, "assets", "logo.txt"))
+	if err != nil || string(logo) != "{{not a template}}\n" {
+		t.Errorf("expected non-template file copied verbatim, got %q (%v)", logo, err)
+	}
+}
+
+func TestScaffold_Errors(t *testing.T) {
+	parent := t.TempDir()
+
+	_, err := Scaffold(parent, "Bad_Name", ScaffoldOptions{})
+	if !errors.Is(err, ErrNameNotLowercase) {
+		t.Errorf("expected invalid name error, got: %v", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(parent, "Bad_Name")); statErr == nil {
+		t.Error("expected nothing to be created for an invalid name")
+	}
+
+	if _, err := Scaffold(parent, "../escape", ScaffoldOptions{}); err == nil {
+		t.Error("expected error for a name containing a path")
+	}
+
+	if _, err := Scaffold(parent, "taken", ScaffoldOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Scaffold(parent, "taken", ScaffoldOptions{}); !errors.Is(err, fs.ErrExist) {
+		t.Errorf("expected fs.ErrExist for existing directory, got: %v", err)
+	}
+
+	bad := fstest.MapFS{"SKILL.md.tmpl": {Data: []byte("{{.Missing}}")}}
+	if _, err := Scaffold(parent, "bad-template", ScaffoldOptions{Template: bad}); err == nil {
+		t.Error("expected template error")
+	}
+}
+
+func TestScaffold_UsesConfig(t *testing.T) {
+	parent := t.TempDir()
+	writeConfig(t, parent, "rules:\n  license-missing: error\nlimits:\n  name: 8\n")
+
+	if _, err := Scaffold(parent, "long-skill-name", ScaffoldOptions{License: "MIT"}); !errors.Is(err, ErrNameTooLong) {
+		t.Errorf("expected the config's name limit to apply, got: %v", err)
+	}
+	if _, err := Scaffold(parent, "short", ScaffoldOptions{}); !errors.Is(err, ErrLicenseMissing) {
+		t.Errorf("expected the config's license-missing severity to apply, got: %v", err)
+	}
+	if _, err := Scaffold(parent, "short", ScaffoldOptions{License: "MIT"}); err != nil {
+		t.Errorf("expected a skill that satisfies the config, got: %v", err)
+	}
+
+	writeConfig(t, parent, "rules:\n  no-such-rule: off\n")
+	if _, err := Scaffold(parent, "other", ScaffoldOptions{}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected an invalid config to be reported, got: %v", err)
+	}
+}
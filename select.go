@@ -0,0 +1,169 @@
+package agentskills
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Selector ranks skills by their relevance to a query, so that a prompt
+// lists only the skills that matter for the task at hand. Relevance is
+// scored with BM25 over the words of each skill's name and description;
+// name words weigh more than description words. Ranking is local: no
+// model or network is involved.
+//
+// A Selector is safe for concurrent use.
+type Selector struct {
+	skills []PromptSkill
+	terms  []map[string]int // term frequencies per skill
+	length []int            // weighted word count per skill
+	avgLen float64
+	df     map[string]int // number of skills containing each term
+}
+
+// SkillMatch is a skill ranked by a Selector.
+type SkillMatch struct {
+	PromptSkill
+
+	// Score is the BM25 relevance of the skill to the query. Scores are
+	// only comparable within one ranking.
+	Score float64
+}
+
+// BM25 parameters, and the weight of a name word relative to a
+// description word.
+const (
+	bm25K1     = 1.2
+	bm25B      = 0.75
+	nameWeight = 3
+)
+
+// NewSelector returns a Selector over skills.
+//
+// Example:
+//
+//	skills, err := agentskills.PromptSkills(dirs)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	selected := agentskills.NewSelector(skills).Select("fill in a PDF form", 5)
+//	result, err := agentskills.RenderPrompt(selected, agentskills.PromptOptions{})
+func NewSelector(skills []PromptSkill) *Selector {
+	s := &Selector{
+		skills: slices.Clone(skills),
+		terms:  make([]map[string]int, len(skills)),
+		length: make([]int, len(skills)),
+		df:     make(map[string]int),
+	}
+	total := 0
+	for i, skill := range skills {
+		tf := make(map[string]int)
+		for _, t := range searchTerms(skill.Name) {
+			tf[t] += nameWeight
+			s.length[i] += nameWeight
+		}
+		for _, t := range searchTerms(skill.Description) {
+			tf[t]++
+			s.length[i]++
+		}
+		for t := range tf {
+			s.df[t]++
+		}
+		s.terms[i] = tf
+		total += s.length[i]
+	}
+	if len(skills) > 0 {
+		s.avgLen = float64(total) / float64(len(skills))
+	}
+	return s
+}
+
+// Rank returns the skills that share at least one word with query, most
+// relevant first. Skills with equal scores keep their order.
+func (s *Selector) Rank(query string) []SkillMatch {
+	var queryTerms []string
+	for _, t := range searchTerms(query) {
+		if !slices.Contains(queryTerms, t) {
+			queryTerms = append(queryTerms, t)
+		}
+	}
+
+	n := float64(len(s.skills))
+	var matches []SkillMatch
+	for i, skill := range s.skills {
+		score := 0.0
+		for _, t := range queryTerms {
+			tf := float64(s.terms[i][t])
+			if tf == 0 {
+				continue
+			}
+			df := float64(s.df[t])
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			norm := 1 - bm25B + bm25B*float64(s.length[i])/s.avgLen
+			score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+		if score > 0 {
+			matches = append(matches, SkillMatch{PromptSkill: skill, Score: score})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b SkillMatch) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	return matches
+}
+
+// Select returns the k skills most relevant to query, most relevant first,
+// ready for RenderPrompt. It returns fewer than k skills when fewer match,
+// and all matching skills when k is zero or negative.
+func (s *Selector) Select(query string, k int) []PromptSkill {
+	matches := s.Rank(query)
+	if k > 0 && len(matches) > k {
+		matches = matches[:k]
+	}
+	skills := make([]PromptSkill, len(matches))
+	for i, m := range matches {
+		skills[i] = m.PromptSkill
+	}
+	return skills
+}
+
+// searchTerms splits text into lower-case words at any character that is
+// not a letter or digit, so that "pdf-reader" yields "pdf" and "reader",
+// and strips common English suffixes so that "extracting", "extracted" and
+// "extracts" match "extract".
+func searchTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = stem(w)
+	}
+	return words
+}
+
+// stem strips a plural or verb suffix from word when at least three
+// characters remain.
+func stem(word string) string {
+	for _, suffix := range []string{"ing", "ed", "es", "s"} {
+		base, ok := strings.CutSuffix(word, suffix)
+		if !ok || len(base) < 3 {
+			continue
+		}
+		switch suffix {
+		case "es":
+			// "boxes" but not "files".
+			if !strings.HasSuffix(base, "x") && !strings.HasSuffix(base, "ch") && !strings.HasSuffix(base, "sh") && !strings.HasSuffix(base, "ss") {
+				continue
+			}
+		case "s":
+			// "files" but not "class" or "status".
+			if strings.HasSuffix(base, "s") || strings.HasSuffix(base, "u") {
+				continue
+			}
+		}
+		return base
+	}
+	return word
+}
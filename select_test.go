@@ -0,0 +1,65 @@
+package agentskills
+
+import (
+	"slices"
+	"testing"
+)
+
+var selectorSkills = []PromptSkill{
+	{SkillProperties: SkillProperties{Name: "pdf-forms", Description: "Fill in and flatten PDF forms."}, Location: "pdf-forms/SKILL.md"},
+	{SkillProperties: SkillProperties{Name: "pdf-reader", Description: "Extract text and tables from PDF files."}, Location: "pdf-reader/SKILL.md"},
+	{SkillProperties: SkillProperties{Name: "spreadsheet", Description: "Create spreadsheets, and read tables from Excel files."}, Location: "spreadsheet/SKILL.md"},
+	{SkillProperties: SkillProperties{Name: "git-helper", Description: "Write commit messages and resolve merge conflicts."}, Location: "git-helper/SKILL.md"},
+}
+
+func selectedNames(skills []PromptSkill) []string {
+	names := make([]string, len(skills))
+	for i, s := range skills {
+		names[i] = s.Name
+	}
+	return names
+}
+
+func TestSelector_Select(t *testing.T) {
+	sel := NewSelector(selectorSkills)
+	tests := []struct {
+		query string
+		k     int
+		want  []string
+	}{
+		{"extracting tables from a PDF", 0, []string{"pdf-reader", "spreadsheet", "pdf-forms"}},
+		{"extracting tables from a PDF", 1, []string{"pdf-reader"}},
+		{"fill the PDF form", 2, []string{"pdf-forms", "pdf-reader"}},
+		{"Resolve a merge conflict", 3, []string{"git-helper"}},
+		{"GIT", 0, []string{"git-helper"}},
+		{"translate a poem", 3, []string{}},
+		{"", 3, []string{}},
+	}
+	for _, tt := range tests {
+		if got := selectedNames(sel.Select(tt.query, tt.k)); !slices.Equal(got, tt.want) {
+			t.Errorf("Select(%q, %d) = %v, want %v", tt.query, tt.k, got, tt.want)
+		}
+	}
+}
+
+func TestSelector_Rank(t *testing.T) {
+	matches := NewSelector(selectorSkills).Rank("pdf")
+	if len(matches) != 2 || matches[0].Name != "pdf-forms" || matches[1].Name != "pdf-reader" {
+		t.Fatalf("unexpected matches: %v", matches)
+	}
+	if matches[0].Score <= 0 || matches[0].Location != "pdf-forms/SKILL.md" {
+		t.Errorf("unexpected first match: %+v", matches[0])
+	}
+
+	if got := NewSelector(nil).Rank("pdf"); len(got) != 0 {
+		t.Errorf("empty selector matched %v", got)
+	}
+}
+
+func TestSearchTerms(t *testing.T) {
+	got := searchTerms("Extracting PDFs, boxes & classes: pdf-reader status files")
+	want := []string{"extract", "pdf", "box", "class", "pdf", "reader", "status", "file"}
+	if !slices.Equal(got, want) {
+		t.Errorf("searchTerms = %v, want %v", got, want)
+	}
+}
@@ -42,3 +42,28 @@ func (p *SkillProperties) ToMap() map[string]any {
 	}
 	return result
 }
+
+// Skill is a fully loaded skill: its properties, the markdown instructions
+// that follow the frontmatter, and the files bundled alongside SKILL.md.
+type Skill struct {
+	// Properties holds the parsed frontmatter fields.
+	Properties *SkillProperties
+
+	// Body is the markdown content after the frontmatter, trimmed of
+	// surrounding whitespace.
+	Body string
+
+	// Dir is the skill directory.
+	Dir string
+
+	// Path is the path to the SKILL.md (or skill.md) file.
+	Path string
+
+	// Frontmatter is the raw YAML text between the --- delimiters.
+	Frontmatter string
+
+	// Resources lists the other files in the skill directory, such as
+	// scripts/ and references/ content, as slash-separated paths relative
+	// to Dir in lexical order.
+	Resources []string
+}
@@ -0,0 +1,87 @@
+package agentskills
+
+import (
+	"encoding/json"
+	"strings"
+	"text/template"
+)
+
+// PromptTemplateData is the data a TemplateRenderer executes its template
+// with.
+type PromptTemplateData struct {
+	Skills []PromptSkill
+}
+
+// TemplateRenderer renders skills through a text/template, so the wording
+// of the prompt can change without code changes. The template is executed
+// with a PromptTemplateData; each skill exposes its name, description,
+// location, license, compatibility, allowed tools and metadata. Values are
+// inserted as is: use the functions of PromptTemplateFuncs to escape them
+// for the surrounding markup.
+type TemplateRenderer struct {
+	Template *template.Template
+}
+
+// NewTemplateRenderer parses text as a prompt template with the functions
+// of PromptTemplateFuncs.
+//
+// Example:
+//
+//	r, err := agentskills.NewTemplateRenderer(`<skills>
+//	{{- range .Skills}}
+//	<skill name="{{xml .Name}}">{{xml .Description}}</skill>
+//	{{- end}}
+//	</skills>`)
+func NewTemplateRenderer(text string) (*TemplateRenderer, error) {
+	tmpl, err := template.New("prompt").Funcs(PromptTemplateFuncs()).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &TemplateRenderer{Template: tmpl}, nil
+}
+
+// Render implements PromptRenderer.
+func (r *TemplateRenderer) Render(skills []PromptSkill) (string, error) {
+	var sb strings.Builder
+	if err := r.Template.Execute(&sb, PromptTemplateData{Skills: skills}); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// PromptTemplateFuncs returns the functions available to prompt templates,
+// in addition to the text/template built-ins:
+//
+//	xml       escapes text for XML element content and double-quoted attributes
+//	json      encodes a value as JSON, such as a quoted string
+//	markdown  escapes Markdown emphasis, code, link and HTML characters
+//	code      formats text as a Markdown code span
+//	oneline   joins lines and collapses runs of whitespace into one space
+func PromptTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"xml":      xmlText,
+		"json":     jsonString,
+		"markdown": markdownEscaper.Replace,
+		"code":     markdownCode,
+		"oneline":  singleLine,
+	}
+}
+
+// xmlText escapes s as XMLRenderer does, for use in element content and
+// double-quoted attribute values.
+func xmlText(s string) string {
+	var sb strings.Builder
+	writeXMLText(&sb, s, true)
+	return sb.String()
+}
+
+// jsonString encodes v as JSON without HTML escaping.
+func jsonString(v any) (string, error) {
+	var sb strings.Builder
+	enc := json.NewEncoder(&sb)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
@@ -0,0 +1,71 @@
+package agentskills
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTemplateRenderer(t *testing.T) {
+	r, err := NewTemplateRenderer(`{{range .Skills -}}
+<skill name="{{xml .Name}}" license={{json .License}}>
+{{xml (oneline .Description)}}
+{{- with .Compatibility}}
+Requires: {{.}}{{end}}
+{{- with .AllowedTools}}
+Tools: {{code .}}{{end}}
+{{- range $k, $v := .Metadata}}
+{{$k}}={{markdown $v}}{{end}}
+</skill>
+{{end}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ToPromptWith([]string{"testdata/special-chars", "testdata/valid-all-fields"}, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<skill name="special-chars" license="">
+Use &lt;foo&gt; &amp; &lt;bar&gt; tags for XML escaping test
+</skill>
+<skill name="valid-all-fields" license="MIT">
+A skill with all optional fields
+Requires: Requires Python 3.11+
+Tools: ` + "`Bash(git:*) Bash(jq:*)`" + `
+author=Test Author
+version=1.0
+</skill>
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTemplateRenderer_Errors(t *testing.T) {
+	if _, err := NewTemplateRenderer("{{range}}"); err == nil {
+		t.Error("expected parse error")
+	}
+
+	r, err := NewTemplateRenderer("{{range .Skills}}{{.Missing}}{{end}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Render([]PromptSkill{{Location: "x"}}); err == nil {
+		t.Error("expected execution error for an unknown field")
+	}
+}
+
+func TestPromptTemplateFuncs_XML(t *testing.T) {
+	xml := PromptTemplateFuncs()["xml"].(func(string) string)
+	for in, want := range map[string]string{
+		`a<b>&"c"`: "a&lt;b&gt;&amp;&quot;c&quot;",
+		"a\x01b":   "a�b",
+		"a\nb":     "a&#xA;b",
+	} {
+		var sb strings.Builder
+		writeXMLText(&sb, in, true)
+		if got := xml(in); got != want || got != sb.String() {
+			t.Errorf("xml(%q) = %q, want %q as XMLRenderer writes it", in, got, want)
+		}
+	}
+}
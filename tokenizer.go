@@ -0,0 +1,50 @@
+package agentskills
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tokenizer counts the tokens a model would see for a text.
+type Tokenizer interface {
+	CountTokens(text string) int
+}
+
+// TokenizerFunc adapts a function to the Tokenizer interface.
+type TokenizerFunc func(text string) int
+
+// CountTokens calls f(text).
+func (f TokenizerFunc) CountTokens(text string) int {
+	return f(text)
+}
+
+// ApproxTokenizer estimates token counts without a model vocabulary. It
+// counts one token per four characters of a word, rounding up, and one per
+// punctuation or symbol character, which is close to the tokenizers of
+// common models for English text and markup. Use a model's own tokenizer
+// when the budget is tight.
+type ApproxTokenizer struct{}
+
+// CountTokens implements Tokenizer.
+func (ApproxTokenizer) CountTokens(text string) int {
+	tokens, word := 0, 0
+	flush := func() {
+		tokens += (word + 3) / 4
+		word = 0
+	}
+	for len(text) > 0 {
+		r, size := utf8.DecodeRuneInString(text)
+		text = text[size:]
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word++
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			tokens++
+		}
+	}
+	flush()
+	return tokens
+}
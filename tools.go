@@ -0,0 +1,107 @@
+package agentskills
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ToolPermission is one entry of the allowed-tools field: a tool, and
+// optionally a pattern restricting how it may be called. "Bash(git:*)"
+// is the tool "Bash" with the pattern "git:*", and "Read" is the tool
+// "Read" with no pattern, allowing any call.
+type ToolPermission struct {
+	Tool    string
+	Pattern string
+}
+
+// String returns the permission as written in allowed-tools.
+func (p ToolPermission) String() string {
+	if p.Pattern == "" {
+		return p.Tool
+	}
+	return p.Tool + "(" + p.Pattern + ")"
+}
+
+// ToolPermissions is a parsed allowed-tools field.
+type ToolPermissions []ToolPermission
+
+// String returns the permissions as an allowed-tools field: the entries
+// separated by spaces. ParseAllowedTools parses it back to ps.
+func (ps ToolPermissions) String() string {
+	entries := make([]string, len(ps))
+	for i, p := range ps {
+		entries[i] = p.String()
+	}
+	return strings.Join(entries, " ")
+}
+
+// ParseAllowedTools parses an allowed-tools field: tool names separated
+// by whitespace, each optionally followed by a pattern in parentheses,
+// such as "Bash(git:*) Bash(jq:*) Read". Tool names consist of letters,
+// digits, underscores, hyphens and dots. Patterns may contain spaces and
+// balanced parentheses, and are kept as written. Errors wrap
+// ErrAllowedToolsInvalid and give the column of the problem.
+func ParseAllowedTools(s string) (ToolPermissions, error) {
+	var ps ToolPermissions
+	i := 0
+	column := func(i int) int { return utf8.RuneCountInString(s[:i]) + 1 }
+	for {
+		for i < len(s) && isSpaceByte(s[i]) {
+			i++
+		}
+		if i == len(s) {
+			return ps, nil
+		}
+
+		start := i
+		for i < len(s) && isToolNameByte(s[i]) {
+			i++
+		}
+		if i == start {
+			r, _ := utf8.DecodeRuneInString(s[i:])
+			return nil, detailf(ErrAllowedToolsInvalid, "allowed-tools: unexpected %q at column %d; expected a tool name",
+				r, column(i))
+		}
+		p := ToolPermission{Tool: s[start:i]}
+
+		if i < len(s) && s[i] == '(' {
+			depth, open := 1, i
+			for i++; i < len(s) && depth > 0; i++ {
+				switch s[i] {
+				case '(':
+					depth++
+				case ')':
+					depth--
+				}
+			}
+			if depth > 0 {
+				return nil, detailf(ErrAllowedToolsInvalid, "allowed-tools: unbalanced parentheses in %q at column %d",
+					s[start:], column(open))
+			}
+			p.Pattern = s[open+1 : i-1]
+			if strings.TrimSpace(p.Pattern) == "" {
+				return nil, detailf(ErrAllowedToolsInvalid, "allowed-tools: empty pattern in %q at column %d; omit the parentheses to allow any call",
+					s[start:i], column(open))
+			}
+		}
+
+		if i < len(s) && !isSpaceByte(s[i]) {
+			r, _ := utf8.DecodeRuneInString(s[i:])
+			if r == ')' {
+				return nil, detailf(ErrAllowedToolsInvalid, "allowed-tools: unbalanced parentheses: unexpected ')' at column %d", column(i))
+			}
+			return nil, detailf(ErrAllowedToolsInvalid, "allowed-tools: unexpected %q after %q at column %d; separate tools with spaces",
+				r, s[start:i], column(i))
+		}
+		ps = append(ps, p)
+	}
+}
+
+func isSpaceByte(b byte) bool {
+	return b < utf8.RuneSelf && unicode.IsSpace(rune(b))
+}
+
+func isToolNameByte(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9' || b == '_' || b == '-' || b == '.'
+}
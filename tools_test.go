@@ -0,0 +1,99 @@
+package agentskills
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseAllowedTools(t *testing.T) {
+	tests := []struct {
+		in   string
+		want ToolPermissions
+	}{
+		{"", nil},
+		{"Bash(git:*) Bash(jq:*)", ToolPermissions{{"Bash", "git:*"}, {"Bash", "jq:*"}}},
+		{"  Read\tWrite\n", ToolPermissions{{"Read", ""}, {"Write", ""}}},
+		{"Bash(git status:*) mcp__github__create_issue", ToolPermissions{{"Bash", "git status:*"}, {"mcp__github__create_issue", ""}}},
+		{"Bash(echo (a) b)", ToolPermissions{{"Bash", "echo (a) b"}}},
+		{"WebFetch(domain:example.com)", ToolPermissions{{"WebFetch", "domain:example.com"}}},
+	}
+	for _, tt := range tests {
+		got, err := ParseAllowedTools(tt.in)
+		if err != nil {
+			t.Errorf("ParseAllowedTools(%q): %v", tt.in, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ParseAllowedTools(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseAllowedTools_Errors(t *testing.T) {
+	tests := map[string]string{
+		"Bash(git:*":         `unbalanced parentheses in "Bash(git:*" at column 5`,
+		"Bash(git:*))":       "unexpected ')' at column 12",
+		"Read, Write":        `unexpected ',' after "Read" at column 5`,
+		"Read;Write":         `unexpected ';' after "Read" at column 5`,
+		"(git:*)":            "unexpected '(' at column 1",
+		"Bash()":             `empty pattern in "Bash()"`,
+		"Bash(git:*)Read":    `unexpected 'R' after "Bash(git:*)" at column 12`,
+		"Read ✓":             "unexpected '✓' at column 6",
+		"Bash(a) Bash((b)":   `unbalanced parentheses in "Bash((b)" at column 13`,
+		"Bash(git:*) ) Read": "unexpected ')' at column 13",
+	}
+	for in, want := range tests {
+		_, err := ParseAllowedTools(in)
+		if !errors.Is(err, ErrAllowedToolsInvalid) || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseAllowedTools(%q) = %v, want error containing %q", in, err, want)
+		}
+	}
+}
+
+func TestToolPermissions_String(t *testing.T) {
+	ps := ToolPermissions{{"Bash", "git:*"}, {"Read", ""}}
+	if got := ps.String(); got != "Bash(git:*) Read" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestAllowedToolsFix(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my-skill")
+	writeSkillMD(t, dir, "SKILL.md", "---\nname: my-skill\ndescription: x\nallowed-tools: Bash(git:*), Read\n---\n")
+	result := Check(dir, ValidateOptions{})
+	if fix := fixesOf(result.Errors)[CodeAllowedToolsInvalid]; fix != "change allowed-tools to 'Bash(git:*) Read'" {
+		t.Fatalf("unexpected fix: %q", fix)
+	}
+	if _, err := ApplyFixes(dir, result.Errors); err != nil {
+		t.Fatal(err)
+	}
+	props, err := ReadProperties(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if props.AllowedTools != "Bash(git:*) Read" {
+		t.Errorf("allowed-tools = %q", props.AllowedTools)
+	}
+}
+
+func FuzzParseAllowedTools(f *testing.F) {
+	for _, s := range []string{"Bash(git:*) Bash(jq:*)", "Read Write", "Bash(echo (a))", "Bash(", "a,b", ""} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		ps, err := ParseAllowedTools(s)
+		if err != nil {
+			return
+		}
+		again, err := ParseAllowedTools(ps.String())
+		if err != nil {
+			t.Fatalf("String() of %q = %q does not parse: %v", s, ps.String(), err)
+		}
+		if !slices.Equal(ps, again) {
+			t.Fatalf("round trip of %q: %v != %v", s, ps, again)
+		}
+	})
+}
@@ -1,14 +1,6 @@
 package agentskills
 
-import (
-	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
-	"unicode"
-
-	"golang.org/x/text/unicode/norm"
-)
+import "io/fs"
 
 // Validation limits per Agent Skills Spec.
 const (
@@ -17,6 +9,10 @@ const (
 	MaxCompatibilityLength = 500
 )
 
+// MinDescriptionLength is the length below which a description is
+// reported as too short to tell agents when to use the skill.
+const MinDescriptionLength = 20
+
 // allowedFields defines the valid frontmatter fields per Agent Skills Spec.
 var allowedFields = map[string]bool{
 	"name":          true,
@@ -27,181 +23,50 @@ var allowedFields = map[string]bool{
 	"compatibility": true,
 }
 
-// validateName validates skill name format and directory match.
-// Skill names support i18n characters (Unicode letters) plus hyphens.
-// Names must be lowercase and cannot start/end with hyphens.
-func validateName(name, skillDir string) []error {
-	var errs []error
-
-	if name == "" {
-		errs = append(errs, ErrNameEmpty)
-		return errs
-	}
-
-	// NFKC normalize the name
-	name = norm.NFKC.String(strings.TrimSpace(name))
-
-	if len(name) > MaxSkillNameLength {
-		errs = append(errs, fmt.Errorf("skill name '%s' exceeds %d character limit (%d chars)",
-			name, MaxSkillNameLength, len(name)))
-	}
-
-	if name != strings.ToLower(name) {
-		errs = append(errs, fmt.Errorf("skill name '%s' must be lowercase", name))
-	}
-
-	if strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-") {
-		errs = append(errs, ErrNameLeadingHyphen)
-	}
-
-	if strings.Contains(name, "--") {
-		errs = append(errs, ErrNameConsecutiveHyphen)
-	}
-
-	// Check that all characters are alphanumeric or hyphen
-	for _, r := range name {
-		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' {
-			errs = append(errs, fmt.Errorf("skill name '%s' contains invalid characters. Only letters, digits, and hyphens are allowed", name))
-			break
-		}
-	}
-
-	// Check directory name matches skill name
-	if skillDir != "" {
-		dirName := norm.NFKC.String(filepath.Base(skillDir))
-		if dirName != name {
-			errs = append(errs, fmt.Errorf("directory name '%s' must match skill name '%s'",
-				filepath.Base(skillDir), name))
-		}
-	}
-
-	return errs
-}
-
-// validateDescription validates description format.
-func validateDescription(description string) []error {
-	var errs []error
-
-	if description == "" {
-		errs = append(errs, ErrDescriptionEmpty)
-		return errs
-	}
-
-	if len(description) > MaxDescriptionLength {
-		errs = append(errs, fmt.Errorf("description exceeds %d character limit (%d chars)",
-			MaxDescriptionLength, len(description)))
-	}
-
-	return errs
-}
-
-// validateCompatibility validates compatibility format.
-func validateCompatibility(compatibility string) []error {
-	var errs []error
-
-	if len(compatibility) > MaxCompatibilityLength {
-		errs = append(errs, fmt.Errorf("compatibility exceeds %d character limit (%d chars)",
-			MaxCompatibilityLength, len(compatibility)))
-	}
-
-	return errs
-}
-
-// validateMetadataFields validates that only allowed fields are present.
-func validateMetadataFields(metadata map[string]any) []error {
-	var errs []error
-	var extraFields []string
-
-	for key := range metadata {
-		if !allowedFields[key] {
-			extraFields = append(extraFields, key)
-		}
-	}
-
-	if len(extraFields) > 0 {
-		allowed := make([]string, 0, len(allowedFields))
-		for k := range allowedFields {
-			allowed = append(allowed, k)
-		}
-		errs = append(errs, fmt.Errorf("unexpected fields in frontmatter: %s. Only %v are allowed",
-			strings.Join(extraFields, ", "), allowed))
-	}
-
-	return errs
-}
-
 // ValidateMetadata validates parsed skill metadata.
 // This is the core validation function that works on already-parsed metadata,
 // avoiding duplicate file I/O when called from the parser.
+// Each returned error is a *ValidationError with SeverityError; warnings
+// and infos are only reported by Check.
 func ValidateMetadata(metadata map[string]any, skillDir string) []error {
-	var errs []error
-
-	errs = append(errs, validateMetadataFields(metadata)...)
-
-	name, hasName := metadata["name"]
-	if !hasName {
-		errs = append(errs, ErrMissingName)
-	} else {
-		nameStr, _ := name.(string)
-		errs = append(errs, validateName(nameStr, skillDir)...)
-	}
-
-	desc, hasDesc := metadata["description"]
-	if !hasDesc {
-		errs = append(errs, ErrMissingDescription)
-	} else {
-		descStr, _ := desc.(string)
-		errs = append(errs, validateDescription(descStr)...)
-	}
-
-	if compat, ok := metadata["compatibility"].(string); ok {
-		errs = append(errs, validateCompatibility(compat)...)
-	}
+	return NewValidator().CheckMetadata(metadata, skillDir).BySeverity(SeverityError)
+}
 
-	return errs
+// ValidateOptions controls Check and CheckFS.
+type ValidateOptions struct {
+	// Strict promotes warnings to errors, so they make validation fail.
+	Strict bool
 }
 
 // Validate validates a skill directory.
 // Returns nil if valid, otherwise returns a ValidationErrors containing
-// all problems found.
+// the findings with SeverityError, which make validation fail; use Check
+// to see warnings and infos as well.
 func Validate(skillDir string) error {
-	info, err := os.Stat(skillDir)
-	if os.IsNotExist(err) {
-		return &ValidationErrors{Errors: []error{
-			fmt.Errorf("path does not exist: %s", skillDir),
-		}}
-	}
-	if err != nil {
-		return &ValidationErrors{Errors: []error{err}}
-	}
-
-	if !info.IsDir() {
-		return &ValidationErrors{Errors: []error{
-			fmt.Errorf("not a directory: %s", skillDir),
-		}}
-	}
-
-	skillMD := findSkillMD(skillDir)
-	if skillMD == "" {
-		return &ValidationErrors{Errors: []error{
-			fmt.Errorf("missing required file: SKILL.md"),
-		}}
-	}
+	return Check(skillDir, ValidateOptions{}).failure()
+}
 
-	content, err := os.ReadFile(skillMD)
-	if err != nil {
-		return &ValidationErrors{Errors: []error{err}}
-	}
+// ValidateFS is like Validate but validates the skill directory dir within
+// fsys, such as an embed.FS, a zip.Reader or an fstest.MapFS.
+func ValidateFS(fsys fs.FS, dir string) error {
+	return CheckFS(fsys, dir, ValidateOptions{}).failure()
+}
 
-	metadata, _, err := parseFrontmatter(string(content))
-	if err != nil {
-		return &ValidationErrors{Errors: []error{err}}
-	}
+// Check validates a skill directory and returns every finding, including
+// warnings and infos. The result is never nil; use its HasErrors or
+// AsError methods to decide whether validation failed.
+func Check(skillDir string, opts ValidateOptions) *ValidationErrors {
+	return check(osSkillFS(skillDir), opts)
+}
 
-	errs := ValidateMetadata(metadata, skillDir)
-	if len(errs) == 0 {
-		return nil
-	}
+// CheckFS is like Check but validates the skill directory dir within fsys.
+func CheckFS(fsys fs.FS, dir string, opts ValidateOptions) *ValidationErrors {
+	return check(newSkillFS(fsys, dir), opts)
+}
 
-	return &ValidationErrors{Errors: errs}
+func check(s skillFS, opts ValidateOptions) *ValidationErrors {
+	v := NewValidator()
+	v.Strict = opts.Strict
+	v.DiscoverConfig = true
+	return v.check(s)
 }
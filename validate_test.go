@@ -1,10 +1,13 @@
 package agentskills
 
 import (
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestValidate_ValidSkill(t *testing.T) {
@@ -171,10 +174,10 @@ func TestValidate_NameDirectoryMismatch(t *testing.T) {
 func TestValidate_UnexpectedFields(t *testing.T) {
 	err := Validate("testdata/unexpected-fields")
 	if err == nil {
-		t.Fatal("expected error for unexpected fields")
+		t.Fatal("expected error for unexpected field")
 	}
-	if !strings.Contains(strings.ToLower(err.Error()), "unexpected fields") {
-		t.Errorf("expected 'unexpected fields' error, got: %v", err)
+	if !strings.Contains(strings.ToLower(err.Error()), "unexpected field") {
+		t.Errorf("expected 'unexpected field' error, got: %v", err)
 	}
 }
 
@@ -327,3 +330,189 @@ func TestValidationErrors_Interface(t *testing.T) {
 		t.Error("expected AsError to return nil for empty")
 	}
 }
+
+func TestValidateFS_ValidSkill(t *testing.T) {
+	fsys := fstest.MapFS{
+		"skills/my-skill/SKILL.md": {Data: []byte("---\nname: my-skill\ndescription: A test skill\n---\nBody\n")},
+	}
+
+	if err := ValidateFS(fsys, "skills/my-skill"); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+}
+
+func TestValidateFS_NameDirectoryMismatch(t *testing.T) {
+	fsys := fstest.MapFS{
+		"wrong-name/SKILL.md": {Data: []byte("---\nname: correct-name\ndescription: A test skill\n---\n")},
+	}
+
+	err := ValidateFS(fsys, "wrong-name")
+	if err == nil {
+		t.Fatal("expected error for name/directory mismatch")
+	}
+	if !strings.Contains(err.Error(), "must match skill name") {
+		t.Errorf("expected 'must match skill name' error, got: %v", err)
+	}
+}
+
+func TestValidateFS_RootSkipsDirectoryMatch(t *testing.T) {
+	// The root of an fs.FS has no directory name to compare against.
+	fsys := fstest.MapFS{
+		"SKILL.md": {Data: []byte("---\nname: my-skill\ndescription: A test skill\n---\n")},
+	}
+
+	if err := ValidateFS(fsys, "."); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+}
+
+func TestValidateFS_PathErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"file.txt": {Data: []byte("not a directory")},
+		"empty":    {Mode: fs.ModeDir},
+	}
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{"missing", "does not exist"},
+		{"file.txt", "not a directory"},
+		{"empty", "SKILL.md"},
+	}
+	for _, tt := range tests {
+		err := ValidateFS(fsys, tt.dir)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ValidateFS(%q): expected %q error, got: %v", tt.dir, tt.want, err)
+		}
+	}
+}
+
+func TestCheck_ReportsWarnings(t *testing.T) {
+	result := Check("testdata/valid-skill", ValidateOptions{})
+	if result.HasErrors() {
+		t.Fatalf("expected no errors, got: %v", result)
+	}
+
+	var codes []Code
+	for _, w := range result.BySeverity(SeverityWarning) {
+		var ve *ValidationError
+		if errors.As(w, &ve) {
+			codes = append(codes, ve.Code)
+		}
+	}
+	if !containsCode(codes, CodeLicenseMissing) {
+		t.Errorf("expected license-missing warning, got %v", codes)
+	}
+}
+
+func TestCheck_NoFindingsForCompleteSkill(t *testing.T) {
+	tmpDir := t.TempDir()
+	skillDir := filepath.Join(tmpDir, "complete-skill")
+	if err := os.Mkdir(skillDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: complete-skill\ndescription: Formats changelogs. Use when preparing a release.\nlicense: MIT\n---\n# Steps\n"
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if result := Check(skillDir, ValidateOptions{Strict: true}); len(result.Errors) != 0 {
+		t.Errorf("expected no findings, got: %v", result)
+	}
+}
+
+func TestCheck_StrictPromotesWarnings(t *testing.T) {
+	tmpDir := t.TempDir()
+	skillDir := filepath.Join(tmpDir, "my-skill")
+	if err := os.Mkdir(skillDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: my-skill\ndescription: Short\nlicense: MIT\n---\n"
+	if err := os.WriteFile(filepath.Join(skillDir, "skill.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := Check(skillDir, ValidateOptions{})
+	if result.HasErrors() {
+		t.Fatalf("expected only warnings, got: %v", result)
+	}
+	codes := validationCodes(t, result)
+	for _, code := range []Code{CodeDescriptionTooShort, CodeSkillMDLowercase, CodeBodyEmpty} {
+		if !containsCode(codes, code) {
+			t.Errorf("expected %s in %v", code, codes)
+		}
+	}
+	if err := Validate(skillDir); err != nil {
+		t.Errorf("expected Validate to pass with warnings only, got: %v", err)
+	}
+
+	strict := Check(skillDir, ValidateOptions{Strict: true})
+	if !strict.HasErrors() {
+		t.Fatal("expected strict mode to fail")
+	}
+	if n := len(strict.BySeverity(SeverityError)); n != 2 {
+		t.Errorf("expected 2 promoted warnings, got %d", n)
+	}
+	if n := len(strict.BySeverity(SeverityInfo)); n != 1 {
+		t.Errorf("expected infos to stay infos, got %d", n)
+	}
+}
+
+func TestValidationErrors_Severity(t *testing.T) {
+	errs := &ValidationErrors{}
+	errs.Add(&ValidationError{Severity: SeverityWarning, Message: "consider this"})
+	errs.Add(&ValidationError{Severity: SeverityInfo, Message: "fyi"})
+
+	if errs.HasErrors() {
+		t.Error("expected warnings and infos not to count as errors")
+	}
+	if errs.AsError() != nil {
+		t.Error("expected AsError to return nil without errors")
+	}
+	if !strings.Contains(errs.Error(), "warning: consider this") {
+		t.Errorf("expected severity prefix in message, got: %s", errs.Error())
+	}
+	if !strings.HasPrefix(errs.Error(), "validation found 1 warning, 1 info:") {
+		t.Errorf("expected counts by severity, got: %s", errs.Error())
+	}
+
+	errs.AddMessage("real problem")
+	if !errs.HasErrors() || errs.AsError() == nil {
+		t.Error("expected an error after AddMessage")
+	}
+	errs.Add(&ValidationError{Severity: SeverityWarning, Message: "consider that"})
+	if !strings.HasPrefix(errs.Error(), "validation failed with 1 error, 2 warnings, 1 info:") {
+		t.Errorf("expected counts by severity, got: %s", errs.Error())
+	}
+}
+
+func TestValidate_ReturnsOnlyErrors(t *testing.T) {
+	err := Validate("testdata/invalid-uppercase")
+	var errs *ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected *ValidationErrors, got %v", err)
+	}
+	if len(errs.Errors) != len(errs.BySeverity(SeverityError)) {
+		t.Errorf("expected only errors, got: %v", errs)
+	}
+	if errors.Is(err, ErrLicenseMissing) {
+		t.Errorf("expected the license-missing warning to be left to Check, got: %v", err)
+	}
+	if !errors.Is(Check("testdata/invalid-uppercase", ValidateOptions{}), ErrLicenseMissing) {
+		t.Error("expected Check to report the license-missing warning")
+	}
+}
+
+func TestSeverity_String(t *testing.T) {
+	for sev, want := range map[Severity]string{
+		SeverityError:   "error",
+		SeverityWarning: "warning",
+		SeverityInfo:    "info",
+		Severity(42):    "unknown",
+	} {
+		if got := sev.String(); got != want {
+			t.Errorf("Severity(%d).String() = %q, want %q", int(sev), got, want)
+		}
+	}
+}
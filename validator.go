@@ -0,0 +1,487 @@
+package agentskills
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Rule is a validation check run by a Validator on the frontmatter and body
+// of a skill. Built-in rules reimplement the checks of the Agent Skills
+// specification; custom rules enforce additional policies.
+type Rule interface {
+	// Info describes the rule. The code must be unique within a Validator,
+	// and the severity is used unless the Validator overrides it.
+	Info() RuleInfo
+
+	// Check inspects the skill and reports findings through ctx.
+	Check(ctx *RuleContext)
+}
+
+// NewRule returns a Rule described by info that runs check.
+func NewRule(info RuleInfo, check func(ctx *RuleContext)) Rule {
+	return &funcRule{info: info, check: check}
+}
+
+type funcRule struct {
+	info  RuleInfo
+	check func(ctx *RuleContext)
+}
+
+func (r *funcRule) Info() RuleInfo         { return r.info }
+func (r *funcRule) Check(ctx *RuleContext) { r.check(ctx) }
+
+// RuleContext gives a rule access to the skill being validated and collects
+// its findings.
+type RuleContext struct {
+	// Dir is the skill directory whose name must match the skill name, or
+	// empty string when the content has no directory of its own.
+	Dir string
+
+	// Path is the display path of the SKILL.md file, or empty string when
+	// the content does not come from a file.
+	Path string
+
+	// Metadata is the parsed frontmatter.
+	Metadata map[string]any
+
+	// Properties holds the known frontmatter fields.
+	Properties *SkillProperties
+
+	// Body is the markdown content after the frontmatter.
+	Body string
+
+	src      *sourceMap
+	info     RuleInfo
+	severity Severity
+	options  map[string]any
+	findings []error
+}
+
+// Report records a finding for field, located at the field's value. Use an
+// empty field for findings about the file as a whole.
+func (c *RuleContext) Report(field string, err error) {
+	pos := Position{File: c.Path}
+	if field != "" {
+		pos = c.ValuePos(field)
+	}
+	c.ReportAt(pos, field, err)
+}
+
+// Reportf is like Report but formats the message. The finding wraps the
+// error returned by fmt.Errorf, so %w verbs are honoured.
+func (c *RuleContext) Reportf(field, format string, args ...any) {
+	c.Report(field, fmt.Errorf(format, args...))
+}
+
+// ReportAt records a finding for field at pos.
+func (c *RuleContext) ReportAt(pos Position, field string, err error) {
+	c.findings = append(c.findings, &ValidationError{
+		Code:     c.info.Code,
+		Severity: c.severity,
+		Field:    field,
+		Message:  err.Error(),
+		Err:      err,
+		Pos:      pos,
+	})
+}
+
+// ReportFix is like Report but attaches a suggested fix to the finding.
+func (c *RuleContext) ReportFix(field string, err error, fix *Fix) {
+	c.Report(field, err)
+	c.attachFix(fix)
+}
+
+// attachFix sets the fix of the last finding.
+func (c *RuleContext) attachFix(fix *Fix) {
+	if ve, ok := c.findings[len(c.findings)-1].(*ValidationError); ok {
+		ve.Fix = fix
+	}
+}
+
+// KeyPos returns the position of the key of field, which may be a dotted
+// path such as "metadata.owner". Absent fields are located at the opening
+// delimiter.
+func (c *RuleContext) KeyPos(field string) Position {
+	if c.src == nil {
+		return Position{File: c.Path}
+	}
+	return c.src.key(field)
+}
+
+// ValuePos returns the position of the value of field, which may be a
+// dotted path such as "metadata.owner". Absent fields are located at the
+// opening delimiter.
+func (c *RuleContext) ValuePos(field string) Position {
+	if c.src == nil {
+		return Position{File: c.Path}
+	}
+	return c.src.value(field)
+}
+
+// Option returns the value of a rule option set with Validator.SetOption.
+func (c *RuleContext) Option(key string) (any, bool) {
+	v, ok := c.options[key]
+	return v, ok
+}
+
+// IntOption returns the integer option key, or def if it is unset or not
+// an integer.
+func (c *RuleContext) IntOption(key string, def int) int {
+	switch v := c.options[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case uint64:
+		return int(v)
+	case float64:
+		if v == float64(int(v)) {
+			return int(v)
+		}
+	}
+	return def
+}
+
+// StringsOption returns the string list option key, or nil if it is unset.
+// A single string is returned as a list of one.
+func (c *RuleContext) StringsOption(key string) []string {
+	return stringsOf(c.options[key])
+}
+
+// stringsOf converts a string or list option value to a string list.
+func stringsOf(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return append([]string(nil), v...)
+	case []any:
+		var out []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// ruleConfig holds the settings of one rule in a Validator.
+type ruleConfig struct {
+	disabled bool
+	severity *Severity
+	options  map[string]any
+}
+
+// Validator runs a configurable set of rules on skills. The zero value has
+// no rules; use NewValidator for one with the built-in rules registered.
+//
+// Checks that must pass before any rule can run (the path exists and is a
+// directory, SKILL.md exists and is readable, the frontmatter parses) are
+// always performed and always report errors.
+//
+// A Validator must not be configured concurrently with its use; once
+// configured, it is safe to use from multiple goroutines.
+type Validator struct {
+	// Strict promotes warnings to errors, so they make validation fail.
+	Strict bool
+
+	// Config, if set, is applied on top of the validator's own settings
+	// for every skill checked, including the overrides matching the skill
+	// directory.
+	Config *Config
+
+	// DiscoverConfig makes the validator look up a .agentskills.yaml file
+	// from each skill directory upwards (see FindConfig) when Config is
+	// nil. Validate and Check enable it.
+	DiscoverConfig bool
+
+	rules  []Rule
+	config map[Code]*ruleConfig
+}
+
+// NewValidator returns a Validator with every built-in rule registered
+// with its default configuration.
+func NewValidator() *Validator {
+	v := &Validator{}
+	for _, info := range builtinRules {
+		if check, ok := builtinChecks[info.Code]; ok {
+			v.rules = append(v.rules, NewRule(info, check))
+		}
+	}
+	return v
+}
+
+// Register adds rules to the validator. Rules run in registration order,
+// after the built-in rules. It returns an error if a rule has no code or
+// its code is already registered.
+func (v *Validator) Register(rules ...Rule) error {
+	for _, r := range rules {
+		code := r.Info().Code
+		if code == "" {
+			return errors.New("rule has no code")
+		}
+		if v.rule(code) != nil {
+			return fmt.Errorf("rule %s is already registered", code)
+		}
+		v.rules = append(v.rules, r)
+	}
+	return nil
+}
+
+// Rules returns the registered rules in the order they run.
+func (v *Validator) Rules() []Rule {
+	rules := make([]Rule, len(v.rules))
+	copy(rules, v.rules)
+	return rules
+}
+
+// rule returns the registered rule with code, or nil.
+func (v *Validator) rule(code Code) Rule {
+	for _, r := range v.rules {
+		if r.Info().Code == code {
+			return r
+		}
+	}
+	return nil
+}
+
+// lookup returns the registered rule whose code or name is key, or nil.
+func (v *Validator) lookup(key string) Rule {
+	for _, r := range v.rules {
+		if info := r.Info(); string(info.Code) == key || info.Name == key {
+			return r
+		}
+	}
+	return nil
+}
+
+// clone returns a copy of v whose configuration can be changed
+// independently.
+func (v *Validator) clone() *Validator {
+	c := *v
+	c.config = make(map[Code]*ruleConfig, len(v.config))
+	for code, cfg := range v.config {
+		cp := *cfg
+		cp.options = maps.Clone(cfg.options)
+		c.config[code] = &cp
+	}
+	return &c
+}
+
+// forSkill returns the validator to use for the skill directory, with the
+// applicable config file applied.
+func (v *Validator) forSkill(s skillFS) (*Validator, error) {
+	cfg := v.Config
+	if cfg == nil && v.DiscoverConfig {
+		var err error
+		if cfg, err = s.findConfig(); err != nil {
+			return nil, err
+		}
+	}
+	if cfg == nil {
+		return v, nil
+	}
+
+	c := v.clone()
+	if err := cfg.apply(c, s.relDir(cfg.dir)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// configFor returns the configuration of code, creating it if needed.
+func (v *Validator) configFor(code Code) *ruleConfig {
+	if v.config == nil {
+		v.config = make(map[Code]*ruleConfig)
+	}
+	cfg, ok := v.config[code]
+	if !ok {
+		cfg = &ruleConfig{}
+		v.config[code] = cfg
+	}
+	return cfg
+}
+
+// Disable turns off the rules with the given codes.
+func (v *Validator) Disable(codes ...Code) {
+	for _, code := range codes {
+		v.configFor(code).disabled = true
+	}
+}
+
+// Enable turns the rules with the given codes back on.
+func (v *Validator) Enable(codes ...Code) {
+	for _, code := range codes {
+		v.configFor(code).disabled = false
+	}
+}
+
+// SetSeverity overrides the severity of the findings of the rule with code.
+func (v *Validator) SetSeverity(code Code, sev Severity) {
+	v.configFor(code).severity = &sev
+}
+
+// SetOption sets a rule-specific option, such as "max" for the length
+// limit of name-too-long (AS001), description-too-long (AS103) and
+// compatibility-too-long (AS201), "min" for description-too-short (AS104)
+// or "allowed" for extra fields accepted by unexpected-field (AS301).
+func (v *Validator) SetOption(code Code, key string, value any) {
+	cfg := v.configFor(code)
+	if cfg.options == nil {
+		cfg.options = make(map[string]any)
+	}
+	cfg.options[key] = value
+}
+
+// Validate validates a skill directory. It returns nil if no rule reports
+// an error, and a *ValidationErrors otherwise.
+func (v *Validator) Validate(skillDir string) error {
+	return v.Check(skillDir).failure()
+}
+
+// ValidateFS is like Validate but validates the skill directory dir within
+// fsys.
+func (v *Validator) ValidateFS(fsys fs.FS, dir string) error {
+	return v.CheckFS(fsys, dir).failure()
+}
+
+// Check validates a skill directory and returns every finding, including
+// warnings and infos. The result is never nil.
+func (v *Validator) Check(skillDir string) *ValidationErrors {
+	return v.check(osSkillFS(skillDir))
+}
+
+// CheckFS is like Check but validates the skill directory dir within fsys.
+func (v *Validator) CheckFS(fsys fs.FS, dir string) *ValidationErrors {
+	return v.check(newSkillFS(fsys, dir))
+}
+
+// CheckMetadata runs the rules on already-parsed frontmatter and returns
+// every finding. Rules that inspect the body or the file name see neither.
+func (v *Validator) CheckMetadata(metadata map[string]any, skillDir string) *ValidationErrors {
+	return &ValidationErrors{Errors: v.runRules(&RuleContext{Dir: skillDir, Metadata: metadata})}
+}
+
+func (v *Validator) check(s skillFS) *ValidationErrors {
+	v, err := v.forSkill(s)
+	if err != nil {
+		pos := Position{File: s.base}
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			pos = parseErr.Pos
+			pos.File = parseErr.Path
+		}
+		return &ValidationErrors{Errors: []error{newValidationError("", pos, err)}}
+	}
+
+	errs := v.checkSkill(s)
+	if v.Strict {
+		for _, err := range errs {
+			var ve *ValidationError
+			if errors.As(err, &ve) && ve.Severity == SeverityWarning {
+				ve.Severity = SeverityError
+			}
+		}
+	}
+	return &ValidationErrors{Errors: errs}
+}
+
+// checkSkill performs the structural checks on the skill directory and,
+// if they pass, runs the rules.
+func (v *Validator) checkSkill(s skillFS) []error {
+	dirPos := Position{File: s.base}
+	fail := func(err error, pos Position) []error {
+		return []error{newValidationError("", pos, err)}
+	}
+
+	info, err := s.stat()
+	if errors.Is(err, fs.ErrNotExist) {
+		return fail(fmt.Errorf("%w: %s", ErrPathNotExist, s.base), dirPos)
+	}
+	if err != nil {
+		return fail(detailf(ErrPathNotExist, "cannot access path: %v", err), dirPos)
+	}
+
+	if !info.IsDir() {
+		return fail(detailf(ErrPathNotDirectory, "not a directory: %s", s.base), dirPos)
+	}
+
+	skillMD := s.findSkillMD()
+	if skillMD == "" {
+		return fail(detailf(ErrSkillMDNotFound, "missing required file: SKILL.md"), dirPos)
+	}
+
+	content, err := fs.ReadFile(s.fsys, path.Join(s.dir, skillMD))
+	if err != nil {
+		return fail(fmt.Errorf("%w: %w", ErrSkillMDUnreadable, err), Position{File: s.path(skillMD)})
+	}
+
+	doc, err := parseDocument(content, s.path(skillMD))
+	if err != nil {
+		var parseErr *ParseError
+		pos := Position{File: s.path(skillMD)}
+		if errors.As(err, &parseErr) {
+			pos = parseErr.Pos
+		}
+		return fail(err, pos)
+	}
+
+	return v.checkDocument(doc, s.nameDir(), s.path(skillMD))
+}
+
+// checkDocument runs the rules on parsed SKILL.md content.
+func (v *Validator) checkDocument(doc *document, skillDir, file string) []error {
+	return v.runRules(&RuleContext{
+		Dir:      skillDir,
+		Path:     file,
+		Metadata: doc.metadata,
+		Body:     doc.body,
+		src:      doc.src,
+	})
+}
+
+// runRules runs every enabled rule with a copy of base.
+func (v *Validator) runRules(base *RuleContext) []error {
+	if base.Properties == nil {
+		base.Properties = collectProperties(base.Metadata)
+	}
+
+	var errs []error
+	for _, r := range v.rules {
+		info := r.Info()
+		cfg := v.config[info.Code]
+		if cfg != nil && cfg.disabled {
+			continue
+		}
+
+		ctx := *base
+		ctx.info = info
+		ctx.severity = info.Severity
+		ctx.findings = nil
+		if cfg != nil {
+			if cfg.severity != nil {
+				ctx.severity = *cfg.severity
+			}
+			ctx.options = cfg.options
+		}
+
+		r.Check(&ctx)
+		errs = append(errs, ctx.findings...)
+	}
+	return errs
+}
+
+// skillName returns the NFKC-normalized skill name, or empty string if the
+// name is missing, empty or not a string.
+func (c *RuleContext) skillName() string {
+	name, _ := c.Metadata["name"].(string)
+	return norm.NFKC.String(strings.TrimSpace(name))
+}
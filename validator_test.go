@@ -0,0 +1,134 @@
+package agentskills
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// skillMapFS returns an in-memory file system holding content as
+// dir/SKILL.md.
+func skillMapFS(dir, content string) fstest.MapFS {
+	return fstest.MapFS{dir + "/SKILL.md": &fstest.MapFile{Data: []byte(content)}}
+}
+
+func TestValidator_MatchesValidate(t *testing.T) {
+	for _, dir := range []string{"testdata/valid-skill", "testdata/invalid-uppercase", "testdata/unexpected-fields"} {
+		want := Check(dir, ValidateOptions{})
+		got := NewValidator().Check(dir)
+		if got.Error() != want.Error() {
+			t.Errorf("%s: Validator.Check = %q, Check = %q", dir, got, want)
+		}
+	}
+}
+
+func TestValidator_CustomRule(t *testing.T) {
+	requireOwner := NewRule(RuleInfo{
+		Code:        "ORG001",
+		Name:        "owner-missing",
+		Description: "metadata.owner is required",
+	}, func(ctx *RuleContext) {
+		if ctx.Properties.Metadata["owner"] == "" {
+			ctx.Report("metadata", errors.New("metadata.owner is required"))
+		}
+	})
+
+	v := NewValidator()
+	if err := v.Register(requireOwner); err != nil {
+		t.Fatal(err)
+	}
+
+	fsys := skillMapFS("my-skill", "---\nname: my-skill\ndescription: Does things when asked to.\nlicense: MIT\nmetadata:\n  team: docs\n---\nBody\n")
+	err := v.ValidateFS(fsys, "my-skill")
+	if err == nil {
+		t.Fatal("expected custom rule to fail")
+	}
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected *ValidationError, got %T", err)
+	}
+	if ve.Code != "ORG001" || ve.Field != "metadata" || ve.Pos.Line != 6 {
+		t.Errorf("unexpected finding: code=%s field=%s pos=%v", ve.Code, ve.Field, ve.Pos)
+	}
+
+	fsys = skillMapFS("my-skill", "---\nname: my-skill\ndescription: Does things when asked to.\nlicense: MIT\nmetadata:\n  owner: docs\n---\nBody\n")
+	if err := v.ValidateFS(fsys, "my-skill"); err != nil {
+		t.Errorf("expected owner to satisfy custom rule, got: %v", err)
+	}
+}
+
+func TestValidator_RegisterDuplicate(t *testing.T) {
+	v := NewValidator()
+	noop := func(*RuleContext) {}
+	if err := v.Register(NewRule(RuleInfo{Code: CodeNameTooLong}, noop)); err == nil {
+		t.Error("expected error registering a built-in code")
+	}
+	if err := v.Register(NewRule(RuleInfo{}, noop)); err == nil {
+		t.Error("expected error registering a rule without code")
+	}
+}
+
+func TestValidator_DisableAndEnable(t *testing.T) {
+	v := NewValidator()
+	v.Disable(CodeNameNotLowercase, CodeNameDirectoryMismatch, CodeLicenseMissing)
+	if err := v.Validate("testdata/invalid-uppercase"); err != nil {
+		t.Errorf("expected disabled rules not to report, got: %v", err)
+	}
+
+	v.Enable(CodeNameNotLowercase)
+	codes := validationCodes(t, v.Validate("testdata/invalid-uppercase"))
+	if len(codes) != 1 || codes[0] != CodeNameNotLowercase {
+		t.Errorf("expected only %s, got %v", CodeNameNotLowercase, codes)
+	}
+}
+
+func TestValidator_SetSeverity(t *testing.T) {
+	v := NewValidator()
+	v.SetSeverity(CodeUnexpectedField, SeverityWarning)
+	result := v.Check("testdata/unexpected-fields")
+	if result.HasErrors() {
+		t.Errorf("expected unexpected fields to be warnings, got: %v", result)
+	}
+
+	v.Strict = true
+	if !v.Check("testdata/unexpected-fields").HasErrors() {
+		t.Error("expected strict mode to promote overridden warnings")
+	}
+
+	v = NewValidator()
+	v.SetSeverity(CodeLicenseMissing, SeverityError)
+	if err := v.Validate("testdata/valid-skill"); err == nil {
+		t.Error("expected license-missing to fail when raised to an error")
+	}
+}
+
+func TestValidator_Options(t *testing.T) {
+	content := "---\nname: my-skill\ndescription: " + strings.Repeat("a", 40) + "\nlicense: MIT\nteam: docs\n---\nBody\n"
+	fsys := skillMapFS("my-skill", content)
+
+	v := NewValidator()
+	v.SetOption(CodeDescriptionTooLong, "max", 30)
+	v.SetOption(CodeUnexpectedField, "allowed", []string{"team"})
+	codes := validationCodes(t, v.ValidateFS(fsys, "my-skill"))
+	if len(codes) != 1 || codes[0] != CodeDescriptionTooLong {
+		t.Errorf("expected only %s, got %v", CodeDescriptionTooLong, codes)
+	}
+
+	v = NewValidator()
+	v.SetOption(CodeDescriptionTooShort, "min", 50)
+	v.SetOption(CodeUnexpectedField, "allowed", "team")
+	result := v.CheckFS(fsys, "my-skill")
+	if !containsCode(validationCodes(t, result), CodeDescriptionTooShort) {
+		t.Errorf("expected raised minimum to report a short description, got: %v", result)
+	}
+}
+
+func TestValidator_CheckMetadata(t *testing.T) {
+	v := NewValidator()
+	v.Disable(CodeLicenseMissing)
+	result := v.CheckMetadata(map[string]any{"name": "my-skill", "description": "Does things when asked to."}, "")
+	if len(result.Errors) != 0 {
+		t.Errorf("expected no findings without body or file, got: %v", result)
+	}
+}